@@ -0,0 +1,58 @@
+package ja
+
+import (
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// Analyzer names.
+const (
+	AnalyzerName    = "ja"
+	AnalyzerUniName = "ja_uni"
+)
+
+func init() {
+	if err := registry.RegisterAnalyzer(AnalyzerName, AnalyzerConstructor); err != nil {
+		panic(err)
+	}
+	if err := registry.RegisterAnalyzer(AnalyzerUniName, AnalyzerUniConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// AnalyzerConstructor returns a Japanese analyzer with the IPA dictionary.
+// It normalizes sentences with NFKC keeping the offsets, replaces inflected words with their base forms,
+// and drops stop tags, stop words, then lowercases terms.
+func AnalyzerConstructor(_ map[string]any, cache *registry.Cache) (analysis.Analyzer, error) { //nolint:ireturn
+	return newAnalyzer(DictIPA, cache)
+}
+
+// AnalyzerUniConstructor returns a Japanese analyzer with the UniDic dictionary.
+// It is the same as the ja analyzer except for the dictionary and the stop tags.
+func AnalyzerUniConstructor(_ map[string]any, cache *registry.Cache) (analysis.Analyzer, error) { //nolint:ireturn
+	return newAnalyzer(DictUni, cache)
+}
+
+func newAnalyzer(dict string, cache *registry.Cache) (*analysis.DefaultAnalyzer, error) {
+	tokenizer, err := TokenizerConstructor(map[string]any{
+		"dict":      dict,
+		"normalize": "nfkc",
+		"base_form": true,
+		"stop_tags": true,
+	}, cache)
+	if err != nil {
+		return nil, err
+	}
+	stopWords, err := cache.TokenFilterNamed(StopWordsName)
+	if err != nil {
+		return nil, err
+	}
+	return &analysis.DefaultAnalyzer{
+		Tokenizer: tokenizer,
+		TokenFilters: []analysis.TokenFilter{
+			stopWords,
+			lowercase.NewLowerCaseFilter(),
+		},
+	}, nil
+}
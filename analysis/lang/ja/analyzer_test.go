@@ -11,6 +11,7 @@ import (
 )
 
 func TestCustomAnalyzer(t *testing.T) {
+	requireDict(t, DictIPA)
 	tests := []struct {
 		title  string
 		input  []byte
@@ -100,6 +101,7 @@ func TestCustomAnalyzer(t *testing.T) {
 }
 
 func BenchmarkJapaneseAnalyzer(b *testing.B) {
+	requireDict(b, DictIPA)
 	im := bleve.NewIndexMapping()
 	if err := im.AddCustomTokenizer("ja", map[string]any{
 		"type":      Name,
@@ -129,3 +131,83 @@ func BenchmarkJapaneseAnalyzer(b *testing.B) {
 		analyzer.Analyze(sen)
 	}
 }
+
+func TestBaseFormBothPhraseSearch(t *testing.T) {
+	requireDict(t, DictIPA)
+	im := bleve.NewIndexMapping()
+	if err := im.AddCustomTokenizer("ja", map[string]any{
+		"type":           Name,
+		"dict":           DictIPA,
+		"base_form":      true,
+		"base_form_mode": FormModeBoth,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := im.AddCustomAnalyzer("ja", map[string]any{
+		"type":      custom.Name,
+		"tokenizer": "ja",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	im.DefaultAnalyzer = "ja"
+	index, err := bleve.NewMemOnly(im)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close() //nolint:errcheck
+	if err := index.Index("1", map[string]any{"text": "踊らされている"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{"踊らされ", "踊る", "踊らされている"} {
+		t.Run(q, func(t *testing.T) {
+			query := bleve.NewMatchPhraseQuery(q)
+			query.SetField("text")
+			result, err := index.Search(bleve.NewSearchRequest(query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Total != 1 {
+				t.Errorf("got %d hits, want 1", result.Total)
+			}
+		})
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	tests := []struct {
+		analyzer string
+		dict     string
+		input    string
+		want     []string
+	}{
+		{
+			analyzer: AnalyzerName,
+			dict:     DictIPA,
+			input:    "ＢＬＥＶＥで私は踊った",
+			want:     []string{"bleve", "私", "踊る"},
+		},
+		{
+			analyzer: AnalyzerUniName,
+			dict:     DictUni,
+			input:    "猫が踊った",
+			want:     []string{"猫", "踊る"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.analyzer, func(t *testing.T) {
+			requireDict(t, tt.dict)
+			im := bleve.NewIndexMapping()
+			analyzer := im.AnalyzerNamed(tt.analyzer)
+			if analyzer == nil {
+				t.Fatal("analyzer is nil")
+			}
+			var got []string
+			for _, v := range analyzer.Analyze([]byte(tt.input)) {
+				got = append(got, string(v.Term))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis/tokenmap"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestTokenizerConstructor_BaseForm(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name     string
+		baseForm any
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "default",
+			baseForm: true,
+			want:     []string{"走る", "まし", "た"},
+		},
+		{
+			name:     "disabled",
+			baseForm: false,
+			want:     []string{"走り", "まし", "た"},
+		},
+		{
+			name:     "token map name",
+			baseForm: "my_inflected",
+			want:     []string{"走る", "ます", "た"},
+		},
+		{
+			name:     "inline list",
+			baseForm: []any{"助動詞"},
+			want:     []string{"走り", "ます", "た"},
+		},
+		{
+			name:     "unknown token map",
+			baseForm: "unknown",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid type",
+			baseForm: map[string]any{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := registry.NewCache()
+			if _, err := cache.DefineTokenMap("my_inflected", map[string]any{
+				"type":   tokenmap.Name,
+				"tokens": []any{"動詞-自立", "助動詞"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			tz, err := TokenizerConstructor(map[string]any{
+				"dict":      DictIPA,
+				"base_form": tt.baseForm,
+			}, cache)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenizerConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := terms(tz.Tokenize([]byte("走りました"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
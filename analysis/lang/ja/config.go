@@ -0,0 +1,76 @@
+package ja
+
+import (
+	"fmt"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// stringsValue returns a list of strings from a config value.
+// A config decoded from JSON has []any instead of []string.
+func stringsValue(v any) ([]string, bool) {
+	switch vv := v.(type) {
+	case []string:
+		return vv, true
+	case []any:
+		ret := make([]string, 0, len(vv))
+		for _, e := range vv {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false
+			}
+			ret = append(ret, s)
+		}
+		return ret, true
+	}
+	return nil, false
+}
+
+// intValue returns an integer from a config value.
+// A number decoded from JSON is float64.
+func intValue(v any) (int, bool) {
+	switch vv := v.(type) {
+	case int:
+		return vv, true
+	case int64:
+		return int(vv), true
+	case float64:
+		if vv != float64(int(vv)) {
+			return 0, false
+		}
+		return int(vv), true
+	}
+	return 0, false
+}
+
+// tokenMapConfig returns a token map from a config value of the key:
+// true for the default token map, a name of a token map in the cache or a list of tokens.
+// It returns nil if the value is false. If defaultMap is nil, true is not allowed.
+func tokenMapConfig(key string, v any, cache *registry.Cache, defaultMap func() (analysis.TokenMap, error)) (analysis.TokenMap, error) {
+	switch vv := v.(type) {
+	case bool:
+		if !vv {
+			return nil, nil
+		}
+		if defaultMap == nil {
+			return nil, fmt.Errorf("%s must be a token map name or a list of strings: %v", key, v)
+		}
+		return defaultMap()
+	case string:
+		m, err := cache.TokenMapNamed(vv)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		return m, nil
+	}
+	tokens, ok := stringsValue(v)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a boolean, a token map name or a list of strings: %v", key, v)
+	}
+	m := analysis.NewTokenMap()
+	for _, token := range tokens {
+		m.AddToken(token)
+	}
+	return m, nil
+}
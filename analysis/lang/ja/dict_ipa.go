@@ -0,0 +1,15 @@
+//go:build !ja_noipa
+
+package ja
+
+import (
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+)
+
+// The IPA dictionary is linked in unless the ja_noipa build tag is given.
+func init() {
+	if err := RegisterDictionary(DictIPA, func() (*dict.Dict, error) { return ipa.Dict(), nil }); err != nil {
+		panic(err)
+	}
+}
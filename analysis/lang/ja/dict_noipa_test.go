@@ -0,0 +1,20 @@
+//go:build ja_noipa
+
+package ja
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestTokenizerConstructor_IPANotLinked(t *testing.T) {
+	_, err := TokenizerConstructor(map[string]any{"dict": DictIPA}, registry.NewCache())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "ja_noipa") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build ja_nouni
+
+package ja
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestTokenizerConstructor_UniNotLinked(t *testing.T) {
+	_, err := TokenizerConstructor(map[string]any{"dict": DictUni}, registry.NewCache())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "ja_nouni") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
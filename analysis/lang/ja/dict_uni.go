@@ -0,0 +1,15 @@
+//go:build !ja_nouni
+
+package ja
+
+import (
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/uni"
+)
+
+// The UniDic dictionary is linked in unless the ja_nouni build tag is given.
+func init() {
+	if err := RegisterDictionary(DictUni, func() (*dict.Dict, error) { return uni.Dict(), nil }); err != nil {
+		panic(err)
+	}
+}
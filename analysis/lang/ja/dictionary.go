@@ -0,0 +1,144 @@
+package ja
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+// builtinDictTags maps the built-in dictionaries to the build tags which exclude them.
+var builtinDictTags = map[string]string{
+	DictIPA: "ja_noipa",
+	DictUni: "ja_nouni",
+}
+
+var dictionaries = struct {
+	sync.RWMutex
+	loaders map[string]func() (*dict.Dict, error)
+}{
+	loaders: map[string]func() (*dict.Dict, error){},
+}
+
+// RegisterDictionary registers a system dictionary which the tokenizer config can refer to by the name in "dict".
+// The loader is called lazily at most once, and the loaded dictionary is shared by all the tokenizers.
+func RegisterDictionary(name string, loader func() (*dict.Dict, error)) error {
+	if name == "" {
+		return errors.New("dictionary name is empty")
+	}
+	if loader == nil {
+		return fmt.Errorf("dictionary loader is nil: %s", name)
+	}
+	dictionaries.Lock()
+	defer dictionaries.Unlock()
+	if _, ok := dictionaries.loaders[name]; ok {
+		return fmt.Errorf("dictionary already registered: %s", name)
+	}
+	dictionaries.loaders[name] = sync.OnceValues(loader)
+	return nil
+}
+
+// DictionaryNamed returns the registered system dictionary of the name.
+func DictionaryNamed(name string) (*dict.Dict, error) {
+	dictionaries.RLock()
+	load, ok := dictionaries.loaders[name]
+	dictionaries.RUnlock()
+	if !ok {
+		if tag, builtin := builtinDictTags[name]; builtin {
+			return nil, fmt.Errorf("dictionary %s is not linked in: the binary is built with the %s tag", name, tag)
+		}
+		return nil, fmt.Errorf("unsupported dictionary: %s", name)
+	}
+	d, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dictionary %s: %w", name, err)
+	}
+	if d == nil {
+		return nil, fmt.Errorf("failed to load dictionary %s: nil dictionary", name)
+	}
+	return d, nil
+}
+
+// dictFile represents a dictionary loaded from a file, with the stat and the checksum of the file.
+type dictFile struct {
+	size    int64
+	modTime time.Time
+	sum     [sha256.Size]byte
+	dict    *dict.Dict
+}
+
+var dictFiles = struct {
+	sync.Mutex
+	files map[string]*dictFile
+}{
+	files: map[string]*dictFile{},
+}
+
+// LoadDictFile loads a compiled kagome dictionary (zip) from the file.
+// The dictionary is loaded once per path and shared while the size and the modification time of the file are unchanged.
+// If they change, the file is hashed, and reloaded only if the checksum changes, replacing the dictionary of the path.
+func LoadDictFile(path string) (*dict.Dict, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary file: %w", err)
+	}
+	dictFiles.Lock()
+	defer dictFiles.Unlock()
+	cached, ok := dictFiles.files[path]
+	if ok && cached.size == fi.Size() && cached.modTime.Equal(fi.ModTime()) {
+		return cached.dict, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary file: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	if ok && cached.sum == sum {
+		cached.size, cached.modTime = fi.Size(), fi.ModTime()
+		return cached.dict, nil
+	}
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dictionary file %s: %w", path, err)
+	}
+	d, err := dict.Load(r, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dictionary file %s: %w", path, err)
+	}
+	dictFiles.files[path] = &dictFile{
+		size:    fi.Size(),
+		modTime: fi.ModTime(),
+		sum:     sum,
+		dict:    d,
+	}
+	return d, nil
+}
+
+// dictConfig returns the system dictionary from the config, dict or dict_path.
+func dictConfig(config map[string]any) (*dict.Dict, error) {
+	kind, hasDict := config["dict"]
+	v, hasPath := config["dict_path"]
+	switch {
+	case hasDict && hasPath:
+		return nil, errors.New("dict and dict_path are mutually exclusive")
+	case hasPath:
+		path, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("dict_path must be a file path: %v", v)
+		}
+		return LoadDictFile(path)
+	case !hasDict:
+		return nil, errors.New(`config requires dict, e.g. "ipa" or "uni", or dict_path`)
+	}
+	name, ok := kind.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported dictionary: %v", kind)
+	}
+	return DictionaryNamed(name)
+}
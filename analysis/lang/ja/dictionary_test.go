@@ -0,0 +1,221 @@
+package ja
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+)
+
+// requireDict skips the test if the built-in dictionary is not linked in by the build tags.
+func requireDict(tb testing.TB, name string) {
+	tb.Helper()
+	dictionaries.RLock()
+	_, ok := dictionaries.loaders[name]
+	dictionaries.RUnlock()
+	if !ok {
+		tb.Skipf("dictionary %s is not linked in", name)
+	}
+}
+
+func TestRegisterDictionary(t *testing.T) {
+	var calls int
+	if err := RegisterDictionary("test_ipa", func() (*dict.Dict, error) {
+		calls++
+		return ipa.Dict(), nil
+	}); err != nil {
+		t.Fatalf("RegisterDictionary() unexpected error: %v", err)
+	}
+	for range 2 {
+		tz, err := TokenizerConstructor(map[string]any{"dict": "test_ipa"}, registry.NewCache())
+		if err != nil {
+			t.Fatalf("TokenizerConstructor() unexpected error: %v", err)
+		}
+		if got, want := terms(tz.Tokenize([]byte("関西国際空港"))), []string{"関西", "国際", "空港"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("loader is called %d times, want 1", calls)
+	}
+	d1, err := DictionaryNamed("test_ipa")
+	if err != nil {
+		t.Fatalf("DictionaryNamed() unexpected error: %v", err)
+	}
+	if d2, _ := DictionaryNamed("test_ipa"); d1 != d2 {
+		t.Error("want the shared dictionary")
+	}
+}
+
+func TestRegisterDictionary_Error(t *testing.T) {
+	loader := func() (*dict.Dict, error) { return ipa.Dict(), nil }
+	t.Run("already registered", func(t *testing.T) {
+		requireDict(t, DictIPA)
+		if err := RegisterDictionary(DictIPA, loader); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("empty name", func(t *testing.T) {
+		if err := RegisterDictionary("", loader); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("nil loader", func(t *testing.T) {
+		if err := RegisterDictionary("test_nil", nil); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("loader error", func(t *testing.T) {
+		errLoad := errors.New("load error")
+		if err := RegisterDictionary("test_error", func() (*dict.Dict, error) { return nil, errLoad }); err != nil {
+			t.Fatalf("RegisterDictionary() unexpected error: %v", err)
+		}
+		if _, err := TokenizerConstructor(map[string]any{"dict": "test_error"}, registry.NewCache()); !errors.Is(err, errLoad) {
+			t.Errorf("got %v, want %v", err, errLoad)
+		}
+	})
+	t.Run("not registered", func(t *testing.T) {
+		if _, err := DictionaryNamed("test_unknown"); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func saveDictFile(t *testing.T, d *dict.Dict) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dict.zip")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck
+	zw := zip.NewWriter(f)
+	if err := d.Save(zw); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDictFile(t *testing.T) {
+	path := saveDictFile(t, ipa.Dict())
+	d1, err := LoadDictFile(path)
+	if err != nil {
+		t.Fatalf("LoadDictFile() unexpected error: %v", err)
+	}
+	d2, err := LoadDictFile(path)
+	if err != nil {
+		t.Fatalf("LoadDictFile() unexpected error: %v", err)
+	}
+	if d1 != d2 {
+		t.Error("want the shared dictionary")
+	}
+	t.Run("dict_path", func(t *testing.T) {
+		tz, err := TokenizerConstructor(map[string]any{"dict_path": path}, registry.NewCache())
+		if err != nil {
+			t.Fatalf("TokenizerConstructor() unexpected error: %v", err)
+		}
+		if got, want := terms(tz.Tokenize([]byte("関西国際空港"))), []string{"関西", "国際", "空港"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("DictFromFile", func(t *testing.T) {
+		tz, err := NewJapaneseTokenizer(nil, DictFromFile(path))
+		if err != nil {
+			t.Fatalf("NewJapaneseTokenizer() unexpected error: %v", err)
+		}
+		if got, want := terms(tz.Tokenize([]byte("関西国際空港"))), []string{"関西", "国際", "空港"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
+
+// rewriteDictFile rewrites the dictionary file with the zip comment, which changes the checksum of the file.
+func rewriteDictFile(t *testing.T, path, comment string) {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close() //nolint:errcheck
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		if err := zw.Copy(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.SetComment(comment); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDictFile_Reload(t *testing.T) {
+	path := saveDictFile(t, ipa.Dict())
+	d1, err := LoadDictFile(path)
+	if err != nil {
+		t.Fatalf("LoadDictFile() unexpected error: %v", err)
+	}
+	t.Run("touched", func(t *testing.T) {
+		modTime := time.Now().Add(time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		d2, err := LoadDictFile(path)
+		if err != nil {
+			t.Fatalf("LoadDictFile() unexpected error: %v", err)
+		}
+		if d1 != d2 {
+			t.Error("want the shared dictionary")
+		}
+	})
+	t.Run("modified", func(t *testing.T) {
+		rewriteDictFile(t, path, "modified")
+		d2, err := LoadDictFile(path)
+		if err != nil {
+			t.Fatalf("LoadDictFile() unexpected error: %v", err)
+		}
+		if d1 == d2 {
+			t.Error("want the reloaded dictionary")
+		}
+		dictFiles.Lock()
+		defer dictFiles.Unlock()
+		if got := dictFiles.files[path].dict; got != d2 {
+			t.Error("want the cache entry of the path replaced")
+		}
+	})
+}
+
+func TestLoadDictFile_Error(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		if _, err := LoadDictFile(filepath.Join(t.TempDir(), "not_found.zip")); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("not a zip file", func(t *testing.T) {
+		if _, err := LoadDictFile("testdata/user_dict.txt"); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("dict and dict_path", func(t *testing.T) {
+		if _, err := TokenizerConstructor(map[string]any{"dict": DictIPA, "dict_path": "dict.zip"}, registry.NewCache()); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
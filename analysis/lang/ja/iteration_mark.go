@@ -0,0 +1,157 @@
+package ja
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+	"golang.org/x/text/unicode/norm"
+)
+
+// IterationMarkCharFilterName is the name of the iteration mark char filter.
+const IterationMarkCharFilterName = "ja_iteration_mark"
+
+func init() {
+	if err := registry.RegisterCharFilter(IterationMarkCharFilterName, IterationMarkCharFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// Iteration marks.
+const (
+	kanjiIterationMark          = '々'
+	hiraganaIterationMark       = 'ゝ'
+	hiraganaVoicedIterationMark = 'ゞ'
+	katakanaIterationMark       = 'ヽ'
+	katakanaVoicedIterationMark = 'ヾ'
+)
+
+func isIterationMark(r rune) bool {
+	switch r {
+	case kanjiIterationMark, hiraganaIterationMark, hiraganaVoicedIterationMark, katakanaIterationMark, katakanaVoicedIterationMark:
+		return true
+	}
+	return false
+}
+
+// IterationMarkCharFilter represents a char filter which expands iteration marks, 々, ゝ, ゞ, ヽ and ヾ,
+// like the JapaneseIterationMarkCharFilter of Lucene.
+// A run of n iteration marks repeats the n characters before the run, e.g. 馬鹿々々しい to 馬鹿馬鹿しい.
+// Marks are left as they are if the characters before them do not match the kinds of the marks,
+// or if the expansion changes the byte length of the input, to keep the offsets of tokens.
+type IterationMarkCharFilter struct {
+	normalizeKanji bool
+	normalizeKana  bool
+}
+
+// NewIterationMarkCharFilter returns an iteration mark char filter.
+func NewIterationMarkCharFilter(normalizeKanji, normalizeKana bool) *IterationMarkCharFilter {
+	return &IterationMarkCharFilter{
+		normalizeKanji: normalizeKanji,
+		normalizeKana:  normalizeKana,
+	}
+}
+
+type runeSpan struct {
+	r      rune
+	offset int
+	size   int
+}
+
+// Filter expands iteration marks of the input.
+func (f *IterationMarkCharFilter) Filter(input []byte) []byte {
+	rs := make([]runeSpan, 0, len(input))
+	var hasMark bool
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRune(input[i:])
+		rs = append(rs, runeSpan{r: r, offset: i, size: size})
+		hasMark = hasMark || isIterationMark(r)
+		i += size
+	}
+	if !hasMark {
+		return input
+	}
+	ret := make([]byte, len(input))
+	copy(ret, input)
+	last := 0 // the end of the last run of the expanded marks.
+	for i := 0; i < len(rs); {
+		if !isIterationMark(rs[i].r) {
+			i++
+			continue
+		}
+		n := 0
+		for i+n < len(rs) && isIterationMark(rs[i+n].r) {
+			n++
+		}
+		// the source characters must not overlap the last run.
+		n = min(n, i-last)
+		if n == 0 {
+			i++
+			continue
+		}
+		for j := i; j < i+n; j++ {
+			mark := rs[j]
+			r, ok := f.expand(mark.r, rs[j-n].r)
+			if !ok || utf8.RuneLen(r) != mark.size {
+				continue
+			}
+			utf8.EncodeRune(ret[mark.offset:], r)
+		}
+		i += n
+		last = i
+	}
+	return ret
+}
+
+// expand returns the character which the mark repeats.
+func (f *IterationMarkCharFilter) expand(mark, source rune) (rune, bool) {
+	switch mark {
+	case kanjiIterationMark:
+		return source, f.normalizeKanji && unicode.Is(unicode.Han, source)
+	case hiraganaIterationMark, hiraganaVoicedIterationMark:
+		if !f.normalizeKana || !unicode.Is(unicode.Hiragana, source) {
+			return 0, false
+		}
+		return kanaSound(source, mark == hiraganaVoicedIterationMark), true
+	case katakanaIterationMark, katakanaVoicedIterationMark:
+		if !f.normalizeKana || !unicode.Is(unicode.Katakana, source) {
+			return 0, false
+		}
+		return kanaSound(source, mark == katakanaVoicedIterationMark), true
+	}
+	return 0, false
+}
+
+// kanaSound returns the voiced kana of r if voiced is true, otherwise the unvoiced one, e.g. か to が and が to か.
+func kanaSound(r rune, voiced bool) rune {
+	var s string
+	if voiced {
+		s = norm.NFC.String(string([]rune{r, combiningVoicedSoundMark}))
+	} else {
+		s = norm.NFD.String(string(r))
+	}
+	ret, size := utf8.DecodeRuneInString(s)
+	if voiced && size != len(s) {
+		return r // r has no voiced kana.
+	}
+	return ret
+}
+
+// IterationMarkCharFilterConstructor returns an iteration mark char filter.
+// The config has normalize_kanji and normalize_kana, both of them are true by default.
+func IterationMarkCharFilterConstructor(config map[string]any, _ *registry.Cache) (analysis.CharFilter, error) { //nolint:ireturn
+	normalizeKanji, normalizeKana := true, true
+	if v, ok := config["normalize_kanji"]; ok {
+		if normalizeKanji, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("normalize_kanji must be a boolean: %v", v)
+		}
+	}
+	if v, ok := config["normalize_kana"]; ok {
+		if normalizeKana, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("normalize_kana must be a boolean: %v", v)
+		}
+	}
+	return NewIterationMarkCharFilter(normalizeKanji, normalizeKana), nil
+}
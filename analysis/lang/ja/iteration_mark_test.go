@@ -0,0 +1,94 @@
+package ja
+
+import (
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestIterationMarkCharFilter_Filter(t *testing.T) {
+	tests := []struct {
+		name           string
+		normalizeKanji bool
+		normalizeKana  bool
+		input          string
+		want           string
+	}{
+		{name: "kanji", normalizeKanji: true, normalizeKana: true, input: "時々", want: "時時"},
+		{name: "kanji run", normalizeKanji: true, normalizeKana: true, input: "馬鹿々々しい", want: "馬鹿馬鹿しい"},
+		{name: "hiragana", normalizeKanji: true, normalizeKana: true, input: "こゝろ", want: "こころ"},
+		{name: "hiragana voiced", normalizeKanji: true, normalizeKana: true, input: "いすゞ", want: "いすず"},
+		{name: "hiragana unvoiced", normalizeKanji: true, normalizeKana: true, input: "ぶゝ", want: "ぶふ"},
+		{name: "hiragana without voiced", normalizeKanji: true, normalizeKana: true, input: "あゞ", want: "ああ"},
+		{name: "katakana", normalizeKanji: true, normalizeKana: true, input: "バヽナ", want: "バハナ"},
+		{name: "katakana voiced", normalizeKanji: true, normalizeKana: true, input: "サヾエ", want: "サザエ"},
+		{name: "mark at the beginning", normalizeKanji: true, normalizeKana: true, input: "々時", want: "々時"},
+		{name: "too many marks", normalizeKanji: true, normalizeKana: true, input: "時々々", want: "時時々"},
+		{name: "kind mismatch", normalizeKanji: true, normalizeKana: true, input: "こ々カゝ", want: "こ々カゝ"},
+		{name: "byte length changes", normalizeKanji: true, normalizeKana: true, input: "𠮷々", want: "𠮷々"},
+		{name: "kanji only", normalizeKanji: true, normalizeKana: false, input: "時々こゝろ", want: "時時こゝろ"},
+		{name: "kana only", normalizeKanji: false, normalizeKana: true, input: "時々こゝろ", want: "時々こころ"},
+		{name: "no marks", normalizeKanji: true, normalizeKana: true, input: "時計", want: "時計"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewIterationMarkCharFilter(tt.normalizeKanji, tt.normalizeKana)
+			got := f.Filter([]byte(tt.input))
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if len(got) != len(tt.input) {
+				t.Errorf("byte length changed: got %d, want %d", len(got), len(tt.input))
+			}
+		})
+	}
+}
+
+func TestIterationMarkCharFilterConstructor(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			config: map[string]any{},
+			want:   "時時こころ",
+		},
+		{
+			name:   "normalize_kanji false",
+			config: map[string]any{"normalize_kanji": false},
+			want:   "時々こころ",
+		},
+		{
+			name:   "normalize_kana false",
+			config: map[string]any{"normalize_kana": false},
+			want:   "時時こゝろ",
+		},
+		{
+			name:    "invalid normalize_kanji",
+			config:  map[string]any{"normalize_kanji": "yes"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid normalize_kana",
+			config:  map[string]any{"normalize_kana": 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := IterationMarkCharFilterConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IterationMarkCharFilterConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := f.Filter([]byte("時々こゝろ")); string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
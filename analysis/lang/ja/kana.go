@@ -0,0 +1,89 @@
+package ja
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	katakanaToHiraganaOffset     = 'ア' - 'あ'
+	combiningVoicedSoundMark     = '\u3099'
+	combiningSemiVoicedSoundMark = '\u309a'
+)
+
+// KatakanaToHiragana converts katakana in the string to hiragana.
+// Katakana which has no hiragana counterpart, e.g. ヷ, is not converted.
+func KatakanaToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'ァ' <= r && r <= 'ヶ', r == 'ヽ', r == 'ヾ':
+			return r - katakanaToHiraganaOffset
+		}
+		return r
+	}, s)
+}
+
+// HiraganaToKatakana converts hiragana in the string to katakana.
+// Hiragana which has no katakana counterpart, e.g. ゟ, is not converted.
+func HiraganaToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'ぁ' <= r && r <= 'ゖ', r == 'ゝ', r == 'ゞ':
+			return r + katakanaToHiraganaOffset
+		}
+		return r
+	}, s)
+}
+
+// halfwidthKatakana represents the full-width katakana of the half-width katakana from U+FF66 (ｦ) to U+FF9F (ﾟ).
+var halfwidthKatakana = []rune("ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン゛゜")
+
+// voicedSoundMarks maps the voiced and semi-voiced sound marks to the combining ones.
+var voicedSoundMarks = map[rune]rune{
+	combiningVoicedSoundMark:     combiningVoicedSoundMark,
+	combiningSemiVoicedSoundMark: combiningSemiVoicedSoundMark,
+	'゛':                          combiningVoicedSoundMark,
+	'゜':                          combiningSemiVoicedSoundMark,
+	'ﾞ':                          combiningVoicedSoundMark,
+	'ﾟ':                          combiningSemiVoicedSoundMark,
+}
+
+// isHalfwidthKatakana returns true if the character is half-width katakana from U+FF66 (ｦ) to U+FF9F (ﾟ).
+func isHalfwidthKatakana(r rune) bool {
+	return 'ｦ' <= r && r <= 'ﾟ'
+}
+
+// HalfwidthToFullwidthKatakana converts half-width katakana in the string to full-width katakana.
+// Kana followed by voiced or semi-voiced sound marks are composed if possible, e.g. ｶﾞ to ガ, ｳﾞ to ヴ and か゛ to が.
+func HalfwidthToFullwidthKatakana(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	var last rune // the last kana which is not written yet.
+	for _, r := range s {
+		if mark, ok := voicedSoundMarks[r]; ok && last != 0 {
+			if c := norm.NFC.String(string([]rune{last, mark})); utf8.RuneCountInString(c) == 1 {
+				b.WriteString(c)
+				last = 0
+				continue
+			}
+		}
+		if last != 0 {
+			b.WriteRune(last)
+			last = 0
+		}
+		if isHalfwidthKatakana(r) {
+			r = halfwidthKatakana[r-'ｦ']
+		}
+		if r == '゛' || r == '゜' {
+			b.WriteRune(r)
+			continue
+		}
+		last = r
+	}
+	if last != 0 {
+		b.WriteRune(last)
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package ja
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// KanaFoldFilterName is the name of the kana fold token filter.
+const KanaFoldFilterName = "ja_kana_fold"
+
+// Kana fold modes.
+const (
+	KanaFoldToHiragana = "to_hiragana"
+	KanaFoldToKatakana = "to_katakana"
+)
+
+func init() {
+	if err := registry.RegisterTokenFilter(KanaFoldFilterName, KanaFoldFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+var kanaFolds = map[string]func(string) string{
+	KanaFoldToHiragana: func(s string) string {
+		return KatakanaToHiragana(HalfwidthToFullwidthKatakana(s))
+	},
+	KanaFoldToKatakana: func(s string) string {
+		return HiraganaToKatakana(HalfwidthToFullwidthKatakana(s))
+	},
+}
+
+// KanaFoldFilter represents a token filter which unifies hiragana, katakana and half-width katakana,
+// e.g. りんご, リンゴ and ﾘﾝｺﾞ to りんご.
+type KanaFoldFilter struct {
+	fold func(string) string
+}
+
+// NewKanaFoldFilter returns a kana fold filter of the mode, to_hiragana or to_katakana.
+func NewKanaFoldFilter(mode string) (*KanaFoldFilter, error) {
+	fold, ok := kanaFolds[strings.ToLower(mode)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported kana fold mode: %s", mode)
+	}
+	return &KanaFoldFilter{
+		fold: fold,
+	}, nil
+}
+
+// Filter folds kana of the terms.
+func (f *KanaFoldFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		token.Term = []byte(f.fold(string(token.Term)))
+	}
+	return input
+}
+
+// KanaFoldFilterConstructor returns a kana fold filter.
+// The config has the mode, to_hiragana (default) or to_katakana.
+func KanaFoldFilterConstructor(config map[string]any, _ *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+	mode := KanaFoldToHiragana
+	if v, ok := config["mode"]; ok {
+		if mode, ok = v.(string); !ok {
+			return nil, fmt.Errorf("unsupported kana fold mode: %v", v)
+		}
+	}
+	return NewKanaFoldFilter(mode)
+}
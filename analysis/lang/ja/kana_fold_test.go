@@ -0,0 +1,99 @@
+package ja
+
+import (
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestHiraganaToKatakana(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "りんご", want: "リンゴ"},
+		{in: "ゔぁいおりん", want: "ヴァイオリン"},
+		{in: "ゕゖゝゞ", want: "ヵヶヽヾ"},
+		{in: "ゟ漢字ABC", want: "ゟ漢字ABC"},
+	}
+	for _, tt := range tests {
+		if got := HiraganaToKatakana(tt.in); got != tt.want {
+			t.Errorf("HiraganaToKatakana(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHalfwidthToFullwidthKatakana(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "ﾘﾝｺﾞ", want: "リンゴ"},
+		{in: "ﾊﾟｰﾃｨｰ", want: "パーティー"},
+		{in: "ｳﾞｧｲｵﾘﾝ", want: "ヴァイオリン"},
+		{in: "ｯｮｦ", want: "ッョヲ"},
+		{in: "ｱﾞ", want: "ア゛"},
+		{in: "ﾞｶ", want: "゛カ"},
+		{in: "か゛ぎく", want: "がぎく"},
+		{in: "ﾎﾟ1ﾟ", want: "ポ1゜"},
+	}
+	for _, tt := range tests {
+		if got := HalfwidthToFullwidthKatakana(tt.in); got != tt.want {
+			t.Errorf("HalfwidthToFullwidthKatakana(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestKanaFoldFilterConstructor(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			config: map[string]any{},
+			want:   []string{"りんご", "りんご", "りんご", "ゔぃーなす", "東京"},
+		},
+		{
+			name:   "to_hiragana",
+			config: map[string]any{"mode": KanaFoldToHiragana},
+			want:   []string{"りんご", "りんご", "りんご", "ゔぃーなす", "東京"},
+		},
+		{
+			name:   "to_katakana",
+			config: map[string]any{"mode": KanaFoldToKatakana},
+			want:   []string{"リンゴ", "リンゴ", "リンゴ", "ヴィーナス", "東京"},
+		},
+		{
+			name:    "unsupported mode",
+			config:  map[string]any{"mode": "to_romaji"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := KanaFoldFilterConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KanaFoldFilterConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			input := analysis.TokenStream{
+				{Term: []byte("りんご")},
+				{Term: []byte("リンゴ")},
+				{Term: []byte("ﾘﾝｺﾞ")},
+				{Term: []byte("ｳﾞｨｰﾅｽ")},
+				{Term: []byte("東京")},
+			}
+			for i, v := range f.Filter(input) {
+				if got := string(v.Term); got != tt.want[i] {
+					t.Errorf("got %q, want %q", got, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package ja
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// KanjiVariantFilterName is the name of the kanji variant token filter.
+const KanjiVariantFilterName = "ja_kanji_variant"
+
+func init() {
+	if err := registry.RegisterTokenFilter(KanjiVariantFilterName, KanjiVariantFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// KanjiVariantsBytes is a list of variant kanji and their canonical forms, e.g. 國 国 and 髙 高.
+//
+//go:embed assets/kanji_variants.txt
+var KanjiVariantsBytes []byte
+
+var defaultKanjiVariants = sync.OnceValues(func() (map[rune]rune, error) {
+	return loadKanjiVariants(KanjiVariantsBytes)
+})
+
+// loadKanjiVariants loads lines of a variant and its canonical form separated by a white space.
+// Empty lines and lines starting with # are ignored.
+func loadKanjiVariants(b []byte) (map[rune]rune, error) {
+	ret := map[rune]rune{}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid kanji variant at line %d: %s", line, text)
+		}
+		variant, canonical, err := kanjiVariantPair(fields[0], fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid kanji variant at line %d: %w", line, err)
+		}
+		ret[variant] = canonical
+	}
+	return ret, s.Err()
+}
+
+func kanjiVariantPair(variant, canonical string) (rune, rune, error) {
+	if utf8.RuneCountInString(variant) != 1 || utf8.RuneCountInString(canonical) != 1 {
+		return 0, 0, fmt.Errorf("variant and canonical form must be a character: %s %s", variant, canonical)
+	}
+	v, _ := utf8.DecodeRuneInString(variant)
+	c, _ := utf8.DecodeRuneInString(canonical)
+	return v, c, nil
+}
+
+// KanjiVariantFilter represents a token filter which folds variant kanji to their canonical forms,
+// e.g. 國 to 国, 髙 to 高 and 齋 to 斎.
+type KanjiVariantFilter struct {
+	variants map[rune]rune
+}
+
+// NewKanjiVariantFilter returns a kanji variant filter with the default variants and the extra mappings
+// from variants to canonical forms. The extra mappings take precedence over the default ones.
+func NewKanjiVariantFilter(extra map[rune]rune) (*KanjiVariantFilter, error) {
+	defaults, err := defaultKanjiVariants()
+	if err != nil {
+		return nil, err
+	}
+	variants := make(map[rune]rune, len(defaults)+len(extra))
+	for k, v := range defaults {
+		variants[k] = v
+	}
+	for k, v := range extra {
+		variants[k] = v
+	}
+	return &KanjiVariantFilter{
+		variants: variants,
+	}, nil
+}
+
+// Filter folds variant kanji of the terms.
+func (f *KanjiVariantFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		token.Term = []byte(strings.Map(func(r rune) rune {
+			if c, ok := f.variants[r]; ok {
+				return c
+			}
+			return r
+		}, string(token.Term)))
+	}
+	return input
+}
+
+// KanjiVariantFilterConstructor returns a kanji variant filter.
+// The config has mappings, an object of extra variants to their canonical forms, e.g. {"﨑": "崎"}.
+func KanjiVariantFilterConstructor(config map[string]any, _ *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+	var extra map[rune]rune
+	if v, ok := config["mappings"]; ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("mappings must be an object: %v", v)
+		}
+		extra = make(map[rune]rune, len(m))
+		for k, v := range m {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("mappings must be an object of strings: %v", v)
+			}
+			variant, canonical, err := kanjiVariantPair(k, s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mappings: %w", err)
+			}
+			extra[variant] = canonical
+		}
+	}
+	return NewKanjiVariantFilter(extra)
+}
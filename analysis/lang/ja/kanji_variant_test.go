@@ -0,0 +1,89 @@
+package ja
+
+import (
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestDefaultKanjiVariants(t *testing.T) {
+	variants, err := defaultKanjiVariants()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range variants {
+		if _, ok := variants[v]; ok {
+			t.Errorf("canonical form %q of %q is a variant", v, k)
+		}
+	}
+}
+
+func TestLoadKanjiVariants_Error(t *testing.T) {
+	for _, in := range []string{"國", "國 国 国", "國國 国"} {
+		if _, err := loadKanjiVariants([]byte(in)); err == nil {
+			t.Errorf("loadKanjiVariants(%q) expected error", in)
+		}
+	}
+}
+
+func TestKanjiVariantFilterConstructor(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			config: map[string]any{},
+			want:   []string{"国学院", "高橋", "斎藤", "渡辺", "渕上", "竜馬"},
+		},
+		{
+			name: "extra mappings",
+			config: map[string]any{
+				"mappings": map[string]any{"渕": "淵", "龍": "龍"},
+			},
+			want: []string{"国学院", "高橋", "斎藤", "渡辺", "淵上", "龍馬"},
+		},
+		{
+			name:    "invalid mappings",
+			config:  map[string]any{"mappings": []any{"渕", "淵"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mapping value",
+			config:  map[string]any{"mappings": map[string]any{"渕": 1}},
+			wantErr: true,
+		},
+		{
+			name:    "not a character",
+			config:  map[string]any{"mappings": map[string]any{"渕上": "淵上"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := KanjiVariantFilterConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KanjiVariantFilterConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			input := analysis.TokenStream{
+				{Term: []byte("國學院")},
+				{Term: []byte("髙橋")},
+				{Term: []byte("齋藤")},
+				{Term: []byte("渡邊")},
+				{Term: []byte("渕上")},
+				{Term: []byte("龍馬")},
+			}
+			for i, v := range f.Filter(input) {
+				if got := string(v.Term); got != tt.want[i] {
+					t.Errorf("got %q, want %q", got, tt.want[i])
+				}
+			}
+		})
+	}
+}
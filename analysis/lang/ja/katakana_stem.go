@@ -0,0 +1,73 @@
+package ja
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// KatakanaStemFilterName is the name of the katakana stem token filter.
+const KatakanaStemFilterName = "ja_katakana_stem"
+
+// DefaultKatakanaStemMinLength is the default minimum length of katakana terms to be stemmed.
+const DefaultKatakanaStemMinLength = 4
+
+func init() {
+	if err := registry.RegisterTokenFilter(KatakanaStemFilterName, KatakanaStemFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// KatakanaStemFilter represents a token filter which removes a trailing prolonged sound mark from katakana terms,
+// e.g. コンピューター to コンピュータ, like the JapaneseKatakanaStemFilter of Lucene.
+type KatakanaStemFilter struct {
+	minLength int
+}
+
+// NewKatakanaStemFilter returns a katakana stem filter.
+// Terms shorter than minLength characters are not stemmed.
+func NewKatakanaStemFilter(minLength int) (*KatakanaStemFilter, error) {
+	if minLength < 2 {
+		return nil, fmt.Errorf("min_length must be greater than or equal to 2: %d", minLength)
+	}
+	return &KatakanaStemFilter{
+		minLength: minLength,
+	}, nil
+}
+
+// Filter removes a trailing prolonged sound mark from katakana terms. Keyword tokens are not stemmed.
+func (f *KatakanaStemFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord || utf8.RuneCount(token.Term) < f.minLength || !isKatakana(token.Term) {
+			continue
+		}
+		if r, size := utf8.DecodeLastRune(token.Term); r == 'ー' {
+			token.Term = token.Term[:len(token.Term)-size]
+		}
+	}
+	return input
+}
+
+// isKatakana returns true if the term consists of the characters of the katakana block, including ー.
+func isKatakana(term []byte) bool {
+	for _, r := range string(term) {
+		if r < '\u30a0' || r > '\u30ff' {
+			return false
+		}
+	}
+	return true
+}
+
+// KatakanaStemFilterConstructor returns a katakana stem filter.
+// The config has min_length, the minimum length of terms to be stemmed (default: 4).
+func KatakanaStemFilterConstructor(config map[string]any, _ *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+	minLength := DefaultKatakanaStemMinLength
+	if v, ok := config["min_length"]; ok {
+		if minLength, ok = intValue(v); !ok {
+			return nil, fmt.Errorf("min_length must be an integer: %v", v)
+		}
+	}
+	return NewKatakanaStemFilter(minLength)
+}
@@ -0,0 +1,80 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestKatakanaStemFilter_Filter(t *testing.T) {
+	f, err := NewKatakanaStemFilter(DefaultKatakanaStemMinLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := analysis.TokenStream{
+		{Term: []byte("コンピューター")},
+		{Term: []byte("コンピュータ")},
+		{Term: []byte("サーバー")},
+		{Term: []byte("カー")},
+		{Term: []byte("ボール")},
+		{Term: []byte("ビールー")},
+		{Term: []byte("ぱーてぃー")},
+		{Term: []byte("Ｔシャツー")},
+		{Term: []byte("プリンター"), KeyWord: true},
+	}
+	want := []string{"コンピュータ", "コンピュータ", "サーバ", "カー", "ボール", "ビール", "ぱーてぃー", "Ｔシャツー", "プリンター"}
+	var got []string
+	for _, v := range f.Filter(input) {
+		got = append(got, string(v.Term))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestKatakanaStemFilterConstructor(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			config: map[string]any{},
+			want:   "カー",
+		},
+		{
+			name:   "min_length",
+			config: map[string]any{"min_length": float64(2)},
+			want:   "カ",
+		},
+		{
+			name:    "too small min_length",
+			config:  map[string]any{"min_length": 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid min_length",
+			config:  map[string]any{"min_length": "4"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := KatakanaStemFilterConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KatakanaStemFilterConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := f.Filter(analysis.TokenStream{{Term: []byte("カー")}})
+			if string(got[0].Term) != tt.want {
+				t.Errorf("got %q, want %q", got[0].Term, tt.want)
+			}
+		})
+	}
+}
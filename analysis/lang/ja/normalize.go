@@ -26,6 +26,8 @@ var forms = map[string]norm.Form{
 }
 
 // UnicodeNormalizeCharFilter represents unicode char filter.
+// Note that the filter may change the byte length of the input, so the offsets of tokens may not match the original text.
+// Use the Normalize tokenizer option to keep the offsets.
 type UnicodeNormalizeCharFilter struct {
 	form norm.Form
 }
@@ -53,3 +55,66 @@ func UnicodeNormalizeCharFilterConstructor(config map[string]any, _ *registry.Ca
 	}
 	return NewUnicodeNormalizeCharFilter(form), nil
 }
+
+// Normalize returns a tokenizer option which normalizes sentences in the form before tokenizing.
+// Unlike the UnicodeNormalizeCharFilter, the offsets of tokens point to the original text.
+func Normalize(form norm.Form) TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		t.normForm = &form
+		return nil
+	}
+}
+
+// normalize returns the sentence normalized in the form.
+// The offsets of the normalized text are mapped to the ones of the input per normalization segment,
+// i.e. a token starting or ending inside a segment is expanded to the whole segment of the input.
+func (s sentence) normalize(form norm.Form) sentence {
+	if form.IsNormalString(s.text) {
+		return s
+	}
+	var (
+		b       strings.Builder
+		offsets = make([]int, 0, len(s.text))
+		ends    = make([]int, 1, len(s.text)+1)
+		it      norm.Iter
+	)
+	ends[0] = s.startOffset(0)
+	// A segment of the input may be emitted as several segments, e.g. ㈱ to (株) in NFKC,
+	// so the offsets are mapped when the input is consumed.
+	from := 0
+	flush := func(to int) {
+		if len(offsets) == b.Len() {
+			return
+		}
+		start, end := s.startOffset(from), s.endOffset(to)
+		for len(offsets) < b.Len() {
+			offsets = append(offsets, start)
+			ends = append(ends, end)
+		}
+		from = to
+	}
+	it.InitString(form, s.text)
+	for !it.Done() {
+		b.Write(it.Next())
+		if to := it.Pos(); to > from {
+			flush(to)
+		}
+	}
+	flush(len(s.text))
+	return sentence{
+		text:    b.String(),
+		start:   s.start,
+		offsets: offsets,
+		ends:    ends,
+	}
+}
+
+// normalizeConfig returns a normalize option from a config value, nfc, nfd, nfkc or nfkd.
+func normalizeConfig(v any) (TokenizerOption, error) {
+	s, _ := v.(string)
+	form, ok := forms[strings.ToLower(s)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported normalize form: %v", v)
+	}
+	return Normalize(form), nil
+}
@@ -0,0 +1,105 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/filter"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestJapaneseTokenizer_Normalize(t *testing.T) {
+	input := []byte("ｶﾞｷﾞは㈱ＡＢＣ　で働く")
+	tests := []struct {
+		name string
+		opts []TokenizerOption
+		want analysis.TokenStream
+	}{
+		{
+			name: "nfkc",
+			opts: []TokenizerOption{Normalize(norm.NFKC)},
+			want: analysis.TokenStream{
+				{Start: 0, End: 12, Term: []byte("ガギ"), Position: 1, Type: analysis.Ideographic},
+				{Start: 12, End: 15, Term: []byte("は"), Position: 2, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("("), Position: 3, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("株"), Position: 4, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte(")"), Position: 5, Type: analysis.Ideographic},
+				{Start: 18, End: 27, Term: []byte("ABC"), Position: 6, Type: analysis.AlphaNumeric},
+				{Start: 27, End: 30, Term: []byte(" "), Position: 7, Type: analysis.Ideographic},
+				{Start: 30, End: 33, Term: []byte("で"), Position: 8, Type: analysis.Ideographic},
+				{Start: 33, End: 39, Term: []byte("働く"), Position: 9, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name: "nfkc and skip white spaces",
+			opts: []TokenizerOption{
+				Normalize(norm.NFKC),
+				SentenceSplitter(filter.SentenceSplitter{Delim: []rune("。"), SkipWhiteSpace: true}),
+			},
+			want: analysis.TokenStream{
+				{Start: 0, End: 12, Term: []byte("ガギ"), Position: 1, Type: analysis.Ideographic},
+				{Start: 12, End: 15, Term: []byte("は"), Position: 2, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("("), Position: 3, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("株"), Position: 4, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte(")"), Position: 5, Type: analysis.Ideographic},
+				{Start: 18, End: 27, Term: []byte("ABC"), Position: 6, Type: analysis.AlphaNumeric},
+				{Start: 30, End: 33, Term: []byte("で"), Position: 7, Type: analysis.Ideographic},
+				{Start: 33, End: 39, Term: []byte("働く"), Position: 8, Type: analysis.Ideographic},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := NewJapaneseTokenizer(ipa.Dict(), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tz.Tokenize(input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerConstructor_Normalize(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "nfkc",
+			config: map[string]any{"dict": DictIPA, "normalize": "nfkc"},
+			want:   []string{"ガギ", "ABC"},
+		},
+		{
+			name:   "nfc",
+			config: map[string]any{"dict": DictIPA, "normalize": "NFC"},
+			want:   []string{"ｶﾞｷﾞ", "ＡＢＣ"},
+		},
+		{
+			name:    "unsupported form",
+			config:  map[string]any{"dict": DictIPA, "normalize": "nfx"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := TokenizerConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenizerConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := terms(tz.Tokenize([]byte("ｶﾞｷﾞＡＢＣ"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package ja
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// NumberFilterName is the name of the number token filter.
+const NumberFilterName = "ja_number"
+
+func init() {
+	if err := registry.RegisterTokenFilter(NumberFilterName, NumberFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// NumberFilter represents a token filter which normalizes Japanese numbers to arabic numbers,
+// e.g. 三千二百, ３，２００, 3.2千 and 三二〇〇 to 3200, like the JapaneseNumberFilter of Lucene.
+// Contiguous numeral tokens are joined into a token which spans them,
+// and the positions of the following tokens are shifted to close the gap, e.g. 三千二百円 and ３，２００円 to 3200 and 円 at 1 and 2.
+// Tokens which cannot be parsed as a number are left as they are.
+type NumberFilter struct{}
+
+// NewNumberFilter returns a number filter.
+func NewNumberFilter() *NumberFilter {
+	return &NumberFilter{}
+}
+
+// Filter normalizes the numbers of the input.
+func (f *NumberFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	ret := make(analysis.TokenStream, 0, len(input))
+	shift := 0 // the number of the positions removed by joining numeral tokens.
+	for i := 0; i < len(input); {
+		if !isNumeral(input[i].Term) {
+			input[i].Position -= shift
+			ret = append(ret, input[i])
+			i++
+			continue
+		}
+		end := i + 1 // the end of the numeral tokens.
+		for j := i + 1; j < len(input) && input[j].Start == input[j-1].End; j++ {
+			if isNumeral(input[j].Term) {
+				end = j + 1
+			} else if !isNumeralPunctuation(input[j].Term) {
+				break
+			}
+		}
+		var b strings.Builder
+		for _, v := range input[i:end] {
+			b.Write(v.Term)
+		}
+		n, err := NormalizeNumber(b.String())
+		if err != nil {
+			for _, v := range input[i:end] {
+				v.Position -= shift
+				ret = append(ret, v)
+			}
+			i = end
+			continue
+		}
+		ret = append(ret, &analysis.Token{
+			Start:    input[i].Start,
+			End:      input[end-1].End,
+			Term:     []byte(n),
+			Position: input[i].Position - shift,
+			Type:     analysis.Numeric,
+			KeyWord:  false,
+		})
+		shift += input[end-1].Position - input[i].Position
+		i = end
+	}
+	return ret
+}
+
+// NumberFilterConstructor returns a number filter.
+func NumberFilterConstructor(_ map[string]any, _ *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+	return NewNumberFilter(), nil
+}
+
+var (
+	kanjiNumerals = map[rune]int64{
+		'〇': 0, '一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+	}
+	// mediumKanjiNumerals maps the kanji numerals to the powers of 10.
+	mediumKanjiNumerals = map[rune]int64{
+		'十': 1, '百': 2, '千': 3,
+	}
+	// largeKanjiNumerals maps the kanji numerals to the powers of 10.
+	largeKanjiNumerals = map[rune]int64{
+		'万': 4, '億': 8, '兆': 12,
+	}
+)
+
+func arabicNumeral(r rune) (int64, bool) {
+	switch {
+	case '0' <= r && r <= '9':
+		return int64(r - '0'), true
+	case '０' <= r && r <= '９':
+		return int64(r - '０'), true
+	}
+	return 0, false
+}
+
+func isDecimalPoint(r rune) bool {
+	return r == '.' || r == '．'
+}
+
+func isThousandSeparator(r rune) bool {
+	return r == ',' || r == '，'
+}
+
+// isNumeral returns true if the term consists of numerals, which may have punctuations.
+func isNumeral(term []byte) bool {
+	var numeral bool
+	for _, r := range string(term) {
+		if _, ok := arabicNumeral(r); ok {
+			numeral = true
+			continue
+		}
+		if _, ok := kanjiNumerals[r]; ok {
+			numeral = true
+			continue
+		}
+		if _, ok := mediumKanjiNumerals[r]; ok {
+			numeral = true
+			continue
+		}
+		if _, ok := largeKanjiNumerals[r]; ok {
+			numeral = true
+			continue
+		}
+		if !isDecimalPoint(r) && !isThousandSeparator(r) {
+			return false
+		}
+	}
+	return numeral
+}
+
+// isNumeralPunctuation returns true if the term consists of decimal points and thousand separators.
+func isNumeralPunctuation(term []byte) bool {
+	if len(term) == 0 {
+		return false
+	}
+	for _, r := range string(term) {
+		if !isDecimalPoint(r) && !isThousandSeparator(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeNumber normalizes a Japanese number to an arabic number, e.g. 3.2千 to 3200 and 1億2千万 to 120000000.
+func NormalizeNumber(s string) (string, error) {
+	p := numberParser{
+		rs: []rune(s),
+	}
+	sum := new(big.Rat)
+	for p.pos < len(p.rs) {
+		n, err := p.parseLargePair()
+		if err != nil {
+			return "", err
+		}
+		if n == nil {
+			return "", errors.New("invalid number: " + s)
+		}
+		sum.Add(sum, n)
+	}
+	if sum.IsInt() {
+		return sum.Num().String(), nil
+	}
+	ret := sum.FloatString(p.scale)
+	return strings.TrimRight(strings.TrimRight(ret, "0"), "."), nil
+}
+
+// numberParser represents a parser of Japanese numbers.
+// A number consists of large pairs, a large pair consists of medium pairs and a large kanji numeral,
+// e.g. 1億2千万 is 1億 + (2千)万, and a medium pair consists of a basic number and a medium kanji numeral.
+type numberParser struct {
+	rs  []rune
+	pos int
+	// scale is the max number of the digits after decimal points.
+	scale int
+}
+
+// parseLargePair parses a pair of a medium number and a large kanji numeral, e.g. 3200万.
+func (p *numberParser) parseLargePair() (*big.Rat, error) {
+	first, err := p.parseMediumNumber()
+	if err != nil {
+		return nil, err
+	}
+	second := p.parseKanjiNumeral(largeKanjiNumerals)
+	switch {
+	case second == nil:
+		return first, nil
+	case first == nil:
+		return second, nil
+	}
+	return first.Mul(first, second), nil
+}
+
+// parseMediumNumber parses a sequence of medium pairs, e.g. 3千2百.
+func (p *numberParser) parseMediumNumber() (*big.Rat, error) {
+	var sum *big.Rat
+	for {
+		n, err := p.parseMediumPair()
+		if err != nil {
+			return nil, err
+		}
+		if n == nil {
+			return sum, nil
+		}
+		if sum == nil {
+			sum = new(big.Rat)
+		}
+		sum.Add(sum, n)
+	}
+}
+
+// parseMediumPair parses a pair of a basic number and a medium kanji numeral, e.g. 3千.
+func (p *numberParser) parseMediumPair() (*big.Rat, error) {
+	first, err := p.parseBasicNumber()
+	if err != nil {
+		return nil, err
+	}
+	second := p.parseKanjiNumeral(mediumKanjiNumerals)
+	switch {
+	case second == nil:
+		return first, nil
+	case first == nil:
+		return second, nil
+	}
+	return first.Mul(first, second), nil
+}
+
+// parseBasicNumber parses a sequence of arabic and kanji numerals, e.g. ３，２００ and 三二〇〇.
+func (p *numberParser) parseBasicNumber() (*big.Rat, error) {
+	var b strings.Builder
+	scale, decimal := 0, false
+	for ; p.pos < len(p.rs); p.pos++ {
+		r := p.rs[p.pos]
+		if n, ok := arabicNumeral(r); ok {
+			b.WriteByte(byte('0' + n))
+		} else if n, ok := kanjiNumerals[r]; ok {
+			b.WriteByte(byte('0' + n))
+		} else if isDecimalPoint(r) {
+			if decimal {
+				return nil, errors.New("invalid number: multiple decimal points")
+			}
+			b.WriteByte('.')
+			decimal = true
+			continue
+		} else if isThousandSeparator(r) {
+			continue
+		} else {
+			break
+		}
+		if decimal {
+			scale++
+		}
+	}
+	if b.Len() == 0 {
+		return nil, nil
+	}
+	n, ok := new(big.Rat).SetString(b.String())
+	if !ok {
+		return nil, errors.New("invalid number: " + b.String())
+	}
+	p.scale = max(p.scale, scale)
+	return n, nil
+}
+
+// parseKanjiNumeral parses a kanji numeral of the powers of 10.
+func (p *numberParser) parseKanjiNumeral(numerals map[rune]int64) *big.Rat {
+	if p.pos >= len(p.rs) {
+		return nil
+	}
+	exp, ok := numerals[p.rs[p.pos]]
+	if !ok {
+		return nil
+	}
+	p.pos++
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+}
@@ -0,0 +1,144 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/ikawaha/kagome-dict/ipa"
+)
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "三千二百", want: "3200"},
+		{in: "３，２００", want: "3200"},
+		{in: "3.2千", want: "3200"},
+		{in: "三二〇〇", want: "3200"},
+		{in: "十", want: "10"},
+		{in: "二十五", want: "25"},
+		{in: "千百十一", want: "1111"},
+		{in: "1億2000万", want: "120000000"},
+		{in: "一兆二千億", want: "1200000000000"},
+		{in: "万", want: "10000"},
+		{in: "１０．５", want: "10.5"},
+		{in: "0.50", want: "0.5"},
+		{in: "1.5万", want: "15000"},
+		{in: "1.2.3", wantErr: true},
+		{in: ".", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeNumber(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeNumber(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeNumber(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNumberFilter_Filter(t *testing.T) {
+	tz, err := NewJapaneseTokenizer(ipa.Dict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  analysis.TokenStream
+	}{
+		{
+			name:  "kanji numerals",
+			input: "三千二百円",
+			want: analysis.TokenStream{
+				{Start: 0, End: 12, Term: []byte("3200"), Position: 1, Type: analysis.Numeric},
+				{Start: 12, End: 15, Term: []byte("円"), Position: 2, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name:  "full-width digits and separators",
+			input: "３，２００円",
+			want: analysis.TokenStream{
+				{Start: 0, End: 15, Term: []byte("3200"), Position: 1, Type: analysis.Numeric},
+				{Start: 15, End: 18, Term: []byte("円"), Position: 2, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name:  "numbers in a sentence",
+			input: "1億2000万円と十五個",
+			want: analysis.TokenStream{
+				{Start: 0, End: 11, Term: []byte("120000000"), Position: 1, Type: analysis.Numeric},
+				{Start: 11, End: 14, Term: []byte("円"), Position: 2, Type: analysis.Ideographic},
+				{Start: 14, End: 17, Term: []byte("と"), Position: 3, Type: analysis.Ideographic},
+				{Start: 17, End: 23, Term: []byte("15"), Position: 4, Type: analysis.Numeric},
+				{Start: 23, End: 26, Term: []byte("個"), Position: 5, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name:  "invalid number",
+			input: "1.2.3",
+			want: analysis.TokenStream{
+				{Start: 0, End: 1, Term: []byte("1"), Position: 1, Type: analysis.Numeric},
+				{Start: 1, End: 2, Term: []byte("."), Position: 2, Type: analysis.Ideographic},
+				{Start: 2, End: 3, Term: []byte("2"), Position: 3, Type: analysis.Numeric},
+				{Start: 3, End: 4, Term: []byte("."), Position: 4, Type: analysis.Ideographic},
+				{Start: 4, End: 5, Term: []byte("3"), Position: 5, Type: analysis.Numeric},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewNumberFilter().Filter(tz.Tokenize([]byte(tt.input)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberFilterPhraseSearch(t *testing.T) {
+	requireDict(t, DictIPA)
+	im := bleve.NewIndexMapping()
+	if err := im.AddCustomTokenizer("ja", map[string]any{
+		"type": Name,
+		"dict": DictIPA,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := im.AddCustomAnalyzer("ja", map[string]any{
+		"type":          custom.Name,
+		"tokenizer":     "ja",
+		"token_filters": []string{NumberFilterName},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	im.DefaultAnalyzer = "ja"
+	index, err := bleve.NewMemOnly(im)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close() //nolint:errcheck
+	if err := index.Index("1", map[string]any{"text": "３，２００円"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{"３，２００円", "三千二百円", "3200円"} {
+		t.Run(q, func(t *testing.T) {
+			query := bleve.NewMatchPhraseQuery(q)
+			query.SetField("text")
+			result, err := index.Search(bleve.NewSearchRequest(query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Total != 1 {
+				t.Errorf("got %d hits, want 1", result.Total)
+			}
+		})
+	}
+}
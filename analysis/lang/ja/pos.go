@@ -0,0 +1,146 @@
+package ja
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// POSMatchMode represents how POS tags of a filter match POSs of tokens.
+type POSMatchMode int
+
+const (
+	// POSMatchExact matches POS tags padded with "*" exactly.
+	POSMatchExact POSMatchMode = iota + 1
+	// POSMatchPrefix matches POS tags as hierarchical prefixes,
+	// e.g. 名詞-固有名詞 matches 名詞-固有名詞-人名-姓, and "*" matches any feature at the level.
+	POSMatchPrefix
+)
+
+// POS match modes.
+const (
+	POSMatchModeExact  = "exact"
+	POSMatchModePrefix = "prefix"
+)
+
+var posMatchModes = map[string]POSMatchMode{
+	POSMatchModeExact:  POSMatchExact,
+	POSMatchModePrefix: POSMatchPrefix,
+}
+
+// posMatcher represents a matcher of POSs.
+type posMatcher interface {
+	Match(p filter.POS) bool
+}
+
+// dropPOS drops tokens whose POS matches.
+func dropPOS(m posMatcher, tokens *[]tokenizer.Token) {
+	filter.Drop(tokens, func(t tokenizer.Token) bool {
+		return m.Match(t.POS())
+	})
+}
+
+// keepPOS keeps tokens whose POS matches.
+func keepPOS(m posMatcher, tokens *[]tokenizer.Token) {
+	filter.Keep(tokens, func(t tokenizer.Token) bool {
+		return m.Match(t.POS())
+	})
+}
+
+// newExactPOSFilter returns a POS filter which matches POS tags padded with "*" exactly.
+func newExactPOSFilter(m analysis.TokenMap) *filter.POSFilter {
+	ps := make([]filter.POS, 0, len(m))
+	for k := range m {
+		pos := strings.Split(k, "-")
+		for i := len(pos); i < posHierarchy; i++ {
+			pos = append(pos, defaultPOSFeature)
+		}
+		ps = append(ps, pos)
+	}
+	return filter.NewPOSFilter(ps...)
+}
+
+// prefixPOSFilter represents a POS filter which matches POS tags as hierarchical prefixes.
+// It holds a kagome POS filter per tag, since a filter does not backtrack from a wildcard to the other tags.
+type prefixPOSFilter []*filter.POSFilter
+
+func newPrefixPOSFilter(m analysis.TokenMap) prefixPOSFilter {
+	ret := make(prefixPOSFilter, 0, len(m))
+	for k := range m {
+		pos := strings.Split(k, "-")
+		for i, v := range pos {
+			if v == defaultPOSFeature {
+				pos[i] = filter.Any
+			}
+		}
+		ret = append(ret, filter.NewPOSFilter(pos))
+	}
+	return ret
+}
+
+// Match returns true if any POS tag of the filter matches the given POS.
+func (f prefixPOSFilter) Match(p filter.POS) bool {
+	for _, v := range f {
+		if v.Match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// posFilterConfig returns POS filter options from the config, stop_tags, keep_tags, base_form and pos_match.
+func posFilterConfig(config map[string]any, cache *registry.Cache, d *dict.Dict) ([]TokenizerOption, error) {
+	// keep_tags match as prefixes by default, since exact tags such as 名詞 would keep nothing.
+	stopTagsFilter, keepTagsFilter, baseFormFilter := StopTagsFilter, KeepTagsPrefixFilter, BaseFormFilter
+	if v, ok := config["pos_match"]; ok {
+		s, _ := v.(string)
+		mode, ok := posMatchModes[strings.ToLower(s)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported pos_match: %v", v)
+		}
+		switch mode {
+		case POSMatchExact:
+			keepTagsFilter = KeepTagsFilter
+		case POSMatchPrefix:
+			stopTagsFilter, baseFormFilter = StopTagsPrefixFilter, BaseFormPrefixFilter
+		}
+	}
+	var opts []TokenizerOption
+	if v, ok := config["stop_tags"]; ok {
+		stopTags, err := tokenMapConfig("stop_tags", v, cache, func() (analysis.TokenMap, error) {
+			return cache.TokenMapNamed(defaultStopTagsName(d))
+		})
+		if err != nil {
+			return nil, err
+		}
+		if stopTags != nil {
+			opts = append(opts, stopTagsFilter(stopTags))
+		}
+	}
+	if v, ok := config["keep_tags"]; ok {
+		keepTags, err := tokenMapConfig("keep_tags", v, cache, nil)
+		if err != nil {
+			return nil, err
+		}
+		if keepTags != nil {
+			opts = append(opts, keepTagsFilter(keepTags))
+		}
+	}
+	if v, ok := config["base_form"]; ok {
+		inflected, err := tokenMapConfig("base_form", v, cache, func() (analysis.TokenMap, error) {
+			return DefaultInflected, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if inflected != nil {
+			opts = append(opts, baseFormFilter(inflected))
+		}
+	}
+	return opts, nil
+}
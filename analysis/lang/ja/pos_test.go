@@ -0,0 +1,182 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestPrefixPOSFilter_Match(t *testing.T) {
+	f := newPrefixPOSFilter(analysis.TokenMap{
+		"名詞-固有名詞":  true,
+		"助詞":       true,
+		"*-*-人名":   true,
+		"動詞-*-*-*": true,
+	})
+	tests := []struct {
+		name string
+		pos  []string
+		want bool
+	}{
+		{
+			name: "match:名詞-固有名詞-地域-一般",
+			pos:  []string{"名詞", "固有名詞", "地域", "一般"},
+			want: true,
+		},
+		{
+			name: "match:助詞-格助詞-一般 (IPA)",
+			pos:  []string{"助詞", "格助詞", "一般", "*"},
+			want: true,
+		},
+		{
+			name: "match:助詞-準体助詞 (UniDic)",
+			pos:  []string{"助詞", "準体助詞", "*", "*"},
+			want: true,
+		},
+		{
+			name: "match:名詞-固有名詞-人名-姓 (wildcard)",
+			pos:  []string{"名詞", "固有名詞", "人名", "姓"},
+			want: true,
+		},
+		{
+			name: "match:名詞-接尾-人名 (wildcard)",
+			pos:  []string{"名詞", "接尾", "人名", "*"},
+			want: true,
+		},
+		{
+			name: "match:動詞-自立",
+			pos:  []string{"動詞", "自立", "*", "*"},
+			want: true,
+		},
+		{
+			name: "not match:名詞-一般",
+			pos:  []string{"名詞", "一般", "*", "*"},
+			want: false,
+		},
+		{
+			name: "not match:shorter POS",
+			pos:  []string{"動詞", "自立"},
+			want: false,
+		},
+		{
+			name: "not match:user dictionary POS",
+			pos:  []string{"カスタム名詞"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.Match(tt.pos); got != tt.want {
+				t.Errorf("Match(%+v) = %v, want %v", tt.pos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerConstructor_POSMatch(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "exact",
+			config: map[string]any{
+				"stop_tags": []any{"名詞-固有名詞", "助詞"},
+				"base_form": []any{"動詞-自立"},
+			},
+			want: []string{"田中", "は", "東京", "へ", "行く", "た"},
+		},
+		{
+			name: "prefix",
+			config: map[string]any{
+				"pos_match": POSMatchModePrefix,
+				"stop_tags": []any{"名詞-固有名詞", "助詞"},
+				"base_form": []any{"動詞-自立"},
+			},
+			want: []string{"行く", "た"},
+		},
+		{
+			name: "prefix with wildcard",
+			config: map[string]any{
+				"pos_match": POSMatchModePrefix,
+				"stop_tags": []any{"*-*-人名", "助詞-*"},
+				"base_form": []any{"*"},
+			},
+			want: []string{"東京", "行く", "た"},
+		},
+		{
+			name: "keep tags",
+			config: map[string]any{
+				"keep_tags": []any{"名詞-固有名詞-人名-姓", "名詞-固有名詞-地域-一般", "動詞-自立"},
+			},
+			want: []string{"田中", "東京", "行っ"},
+		},
+		{
+			name: "keep tags with prefix",
+			config: map[string]any{
+				"pos_match": POSMatchModePrefix,
+				"keep_tags": []any{"名詞", "動詞"},
+				"base_form": true,
+			},
+			want: []string{"田中", "東京", "行く"},
+		},
+		{
+			name: "keep tags with default pos_match",
+			config: map[string]any{
+				"keep_tags": []any{"名詞", "動詞"},
+			},
+			want: []string{"田中", "東京", "行っ"},
+		},
+		{
+			name: "keep tags with exact",
+			config: map[string]any{
+				"pos_match": POSMatchModeExact,
+				"keep_tags": []any{"名詞", "動詞-自立"},
+			},
+			want: []string{"行っ"},
+		},
+		{
+			name: "keep tags and stop tags",
+			config: map[string]any{
+				"pos_match": POSMatchModePrefix,
+				"keep_tags": []any{"名詞"},
+				"stop_tags": []any{"*-*-人名"},
+			},
+			want: []string{"東京"},
+		},
+		{
+			name: "keep tags must not be true",
+			config: map[string]any{
+				"keep_tags": true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported pos_match",
+			config: map[string]any{
+				"pos_match": "regexp",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["dict"] = DictIPA
+			tz, err := TokenizerConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenizerConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := terms(tz.Tokenize([]byte("田中は東京へ行った"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
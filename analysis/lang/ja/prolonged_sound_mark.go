@@ -0,0 +1,83 @@
+package ja
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// ProlongedSoundMarkCharFilterName is the name of the prolonged sound mark char filter.
+const ProlongedSoundMarkCharFilterName = "ja_prolonged_sound_mark"
+
+func init() {
+	if err := registry.RegisterCharFilter(ProlongedSoundMarkCharFilterName, ProlongedSoundMarkCharFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// isProlongedSoundMarkLike returns true if the character is a prolonged sound mark or a dash used as it,
+// ー, ｰ, ―, ‐, −, 〜 and ～.
+func isProlongedSoundMarkLike(r rune) bool {
+	switch r {
+	case 'ー', 'ｰ', '―', '‐', '−', '〜', '～':
+		return true
+	}
+	return false
+}
+
+// ProlongedSoundMarkCharFilter represents a char filter which normalizes prolonged sound marks and dashes.
+// They are normalized to ー if they follow kana, e.g. ラ～メン to ラーメン, or to ｰ if they follow half-width katakana,
+// e.g. ﾗ～ﾒﾝ to ﾗｰﾒﾝ, otherwise to a hyphen-minus.
+// The hyphen-minus is padded with spaces to keep the byte length of the input.
+type ProlongedSoundMarkCharFilter struct{}
+
+// NewProlongedSoundMarkCharFilter returns a prolonged sound mark char filter.
+func NewProlongedSoundMarkCharFilter() *ProlongedSoundMarkCharFilter {
+	return &ProlongedSoundMarkCharFilter{}
+}
+
+// Filter normalizes prolonged sound marks and dashes of the input.
+func (f *ProlongedSoundMarkCharFilter) Filter(input []byte) []byte {
+	var ret []byte
+	var kana bool      // true if the last character is kana or a prolonged sound mark following kana.
+	var halfwidth bool // true if the kana is half-width katakana.
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRune(input[i:])
+		if !isProlongedSoundMarkLike(r) {
+			// voiced sound marks, e.g. ﾞ of ｶﾞ, continue the kana before them.
+			if _, ok := voicedSoundMarks[r]; !ok {
+				kana = unicode.In(r, unicode.Hiragana, unicode.Katakana)
+				halfwidth = isHalfwidthKatakana(r)
+			}
+			i += size
+			continue
+		}
+		if ret == nil {
+			ret = make([]byte, len(input))
+			copy(ret, input)
+		}
+		switch {
+		case kana && halfwidth:
+			utf8.EncodeRune(ret[i:], 'ｰ') // all the marks, ー and ｰ are 3 bytes.
+		case kana:
+			utf8.EncodeRune(ret[i:], 'ー')
+		default:
+			ret[i] = '-'
+			for j := i + 1; j < i+size; j++ {
+				ret[j] = ' '
+			}
+		}
+		i += size
+	}
+	if ret == nil {
+		return input
+	}
+	return ret
+}
+
+// ProlongedSoundMarkCharFilterConstructor returns a prolonged sound mark char filter.
+func ProlongedSoundMarkCharFilterConstructor(_ map[string]any, _ *registry.Cache) (analysis.CharFilter, error) { //nolint:ireturn
+	return NewProlongedSoundMarkCharFilter(), nil
+}
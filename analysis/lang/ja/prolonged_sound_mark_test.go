@@ -0,0 +1,44 @@
+package ja
+
+import (
+	"testing"
+)
+
+func TestProlongedSoundMarkCharFilter_Filter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "prolonged sound mark", input: "ラーメン", want: "ラーメン"},
+		{name: "half-width prolonged sound mark", input: "ラｰメン", want: "ラーメン"},
+		{name: "horizontal bar", input: "ラ―メン", want: "ラーメン"},
+		{name: "hyphen", input: "ラ‐メン", want: "ラーメン"},
+		{name: "minus sign", input: "ラ−メン", want: "ラーメン"},
+		{name: "wave dash", input: "すご〜い", want: "すごーい"},
+		{name: "full-width tilde", input: "すご～い", want: "すごーい"},
+		{name: "half-width katakana", input: "ﾗ～ﾒﾝ", want: "ﾗｰﾒﾝ"},
+		{name: "after half-width voiced sound mark", input: "ｶﾞｰﾄﾞ", want: "ｶﾞｰﾄﾞ"},
+		{name: "after combining voiced sound mark", input: "ガ\u3099ー", want: "ガ\u3099ー"},
+		{name: "full-width mark after half-width katakana", input: "ｶﾞーﾄﾞ", want: "ｶﾞｰﾄﾞ"},
+		{name: "half-width mark after full-width katakana", input: "ラｰメンとﾗｰﾒﾝ", want: "ラーメンとﾗｰﾒﾝ"},
+		{name: "after voiced sound mark", input: "か゛〜", want: "か゛ー"},
+		{name: "repeated marks", input: "ね〜〜", want: "ねーー"},
+		{name: "after kanji", input: "東京〜大阪", want: "東京-  大阪"},
+		{name: "after digits", input: "1−3", want: "1-  3"},
+		{name: "at the beginning", input: "―と", want: "-  と"},
+		{name: "no marks", input: "ラメン", want: "ラメン"},
+	}
+	f := NewProlongedSoundMarkCharFilter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := f.Filter([]byte(tt.input))
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if len(got) != len(tt.input) {
+				t.Errorf("byte length changed: got %d, want %d", len(got), len(tt.input))
+			}
+		})
+	}
+}
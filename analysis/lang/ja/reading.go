@@ -0,0 +1,198 @@
+package ja
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Reading scripts.
+const (
+	ReadingKatakana = "katakana"
+	ReadingHiragana = "hiragana"
+	ReadingRomaji   = "romaji"
+)
+
+var readingConverters = map[string]func(string) string{
+	ReadingKatakana: nil,
+	ReadingHiragana: KatakanaToHiragana,
+}
+
+// readingForm represents a reading form option.
+type readingForm struct {
+	mode FormMode
+	conv func(string) string
+}
+
+// ReadingForm returns a reading form option.
+// The reading (katakana) is taken from the token features and converted by conv if conv is not nil.
+// The surface is used for words which have no reading, e.g. unknown words.
+func ReadingForm(mode FormMode, conv func(string) string) TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		if !mode.valid() {
+			return fmt.Errorf("unsupported reading form mode: %v", mode)
+		}
+		t.readingForm = &readingForm{
+			mode: mode,
+			conv: conv,
+		}
+		return nil
+	}
+}
+
+// LemmaReadingIndex is the contents meta key of the feature index of the reading of the lemma (lForm).
+// Dictionaries which have the feature layout of the UniDic can declare it in the contents meta.
+const LemmaReadingIndex = "_lemma_reading"
+
+const (
+	// uniDicName is the name in the dictionary info of the UniDic bundled with kagome.
+	uniDicName = "Uni"
+	// uniDicLemmaReading is the feature index of the reading of the lemma of the bundled UniDic,
+	// which does not declare it in the contents meta.
+	uniDicLemmaReading = 6
+)
+
+// readingLayout represents indexes of the reading features of a dictionary.
+//
+// The IPA dictionary has the reading of the surface.
+// The UniDic has the reading of the lemma (lForm) and the pronunciation of the surface.
+// The reading of the lemma is used for words which are not inflected, and the pronunciation is used for the others.
+// The surface is used for dictionaries whose layout is unknown.
+type readingLayout struct {
+	surfaceReading   int
+	lemmaReading     int
+	inflectionalForm int
+	pronunciation    int
+}
+
+// isUniDicLayout returns true if the dictionary has the feature layout of the UniDic,
+// i.e. it is the bundled UniDic or it declares the reading of the lemma in the contents meta.
+func isUniDicLayout(d *dict.Dict) bool {
+	if _, ok := d.ContentsMeta[LemmaReadingIndex]; ok {
+		return true
+	}
+	info := d.Info()
+	return info != nil && info.Name == uniDicName
+}
+
+func newReadingLayout(d *dict.Dict) readingLayout {
+	index := func(key string) int {
+		if i, ok := d.ContentsMeta[key]; ok {
+			return int(i)
+		}
+		return -1
+	}
+	ret := readingLayout{
+		surfaceReading:   index(dict.ReadingIndex),
+		lemmaReading:     -1,
+		inflectionalForm: -1,
+		pronunciation:    -1,
+	}
+	if ret.surfaceReading >= 0 || !isUniDicLayout(d) {
+		return ret
+	}
+	ret.lemmaReading = index(LemmaReadingIndex)
+	if ret.lemmaReading < 0 {
+		ret.lemmaReading = uniDicLemmaReading
+	}
+	ret.inflectionalForm = index(dict.InflectionalForm)
+	ret.pronunciation = index(dict.PronunciationIndex)
+	return ret
+}
+
+// reading returns the reading of the token.
+func (l readingLayout) reading(token tokenizer.Token) (string, bool) {
+	switch token.Class {
+	case tokenizer.USER:
+		if extra := token.UserExtra(); extra != nil {
+			return validReading(strings.Join(extra.Readings, ""))
+		}
+	case tokenizer.KNOWN:
+		if l.surfaceReading >= 0 {
+			return validFeatureAt(token, l.surfaceReading)
+		}
+		if l.lemmaReading >= 0 && l.inflectionalForm >= 0 {
+			if form, ok := token.FeatureAt(l.inflectionalForm); ok && form == defaultPOSFeature {
+				return validFeatureAt(token, l.lemmaReading)
+			}
+		}
+		if l.pronunciation >= 0 {
+			return validFeatureAt(token, l.pronunciation)
+		}
+	case tokenizer.DUMMY, tokenizer.UNKNOWN:
+	}
+	return "", false
+}
+
+// appendReading applies the reading form option to the token and
+// appends the reading token to the token stream if necessary.
+func (t *JapaneseTokenizer) appendReading(ts analysis.TokenStream, token *analysis.Token, v tokenizer.Token, pos []string) analysis.TokenStream {
+	reading, ok := t.readingLayout.reading(v)
+	if !ok {
+		reading = v.Surface
+	}
+	if t.readingForm.conv != nil {
+		if s := t.readingForm.conv(reading); s != "" {
+			reading = s
+		}
+	}
+	switch t.readingForm.mode {
+	case FormReplace:
+		token.Term = []byte(reading)
+		token.Type = TokenType(reading, pos)
+	case FormBoth:
+		if reading != string(token.Term) {
+			ts = append(ts, &analysis.Token{
+				Start:    token.Start,
+				End:      token.End,
+				Term:     []byte(reading),
+				Position: token.Position,
+				Type:     TokenType(reading, pos),
+				KeyWord:  false,
+			})
+		}
+	}
+	return ts
+}
+
+func validFeatureAt(token tokenizer.Token, i int) (string, bool) {
+	if v, ok := token.FeatureAt(i); ok {
+		return validReading(v)
+	}
+	return "", false
+}
+
+func validReading(s string) (string, bool) {
+	if s == "" || s == defaultPOSFeature {
+		return "", false
+	}
+	return s, true
+}
+
+// readingFormConfig returns a reading form option from the config,
+// reading, reading_mode and romaji_system and collapse_long_vowels for romaji.
+func readingFormConfig(config map[string]any) (TokenizerOption, error) {
+	s, _ := config["reading"].(string)
+	conv, ok := readingConverters[strings.ToLower(s)]
+	if !ok && strings.ToLower(s) != ReadingRomaji {
+		return nil, fmt.Errorf("unsupported reading: %v", config["reading"])
+	}
+	if !ok {
+		c, err := romajiConverterConfig(config["romaji_system"], config["collapse_long_vowels"])
+		if err != nil {
+			return nil, err
+		}
+		conv = c.Convert
+	}
+	m := FormReplace
+	if mode, ok := config["reading_mode"]; ok {
+		s, _ := mode.(string)
+		if m, ok = formModes[strings.ToLower(s)]; !ok {
+			return nil, fmt.Errorf("unsupported reading_mode: %v", mode)
+		}
+	}
+	return ReadingForm(m, conv), nil
+}
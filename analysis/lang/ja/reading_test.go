@@ -0,0 +1,222 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+)
+
+func TestJapaneseTokenizer_ReadingForm(t *testing.T) {
+	input := []byte("経済を学んだエルフェンリート")
+	tests := []struct {
+		name string
+		dict *dict.Dict
+		opt  TokenizerOption
+		want analysis.TokenStream
+	}{
+		{
+			name: "ipa: replace with hiragana",
+			dict: ipa.Dict(),
+			opt:  ReadingForm(FormReplace, KatakanaToHiragana),
+			want: analysis.TokenStream{
+				{Start: 0, End: 6, Term: []byte("けいざい"), Position: 1, Type: analysis.Ideographic},
+				{Start: 6, End: 9, Term: []byte("を"), Position: 2, Type: analysis.Ideographic},
+				{Start: 9, End: 15, Term: []byte("まなん"), Position: 3, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("だ"), Position: 4, Type: analysis.Ideographic},
+				{Start: 18, End: 42, Term: []byte("えるふぇんりーと"), Position: 5, Type: analysis.Ideographic}, // Note: unknown word
+			},
+		},
+		{
+			name: "ipa: both",
+			dict: ipa.Dict(),
+			opt:  ReadingForm(FormBoth, nil),
+			want: analysis.TokenStream{
+				{Start: 0, End: 6, Term: []byte("経済"), Position: 1, Type: analysis.Ideographic},
+				{Start: 0, End: 6, Term: []byte("ケイザイ"), Position: 1, Type: analysis.Ideographic},
+				{Start: 6, End: 9, Term: []byte("を"), Position: 2, Type: analysis.Ideographic},
+				{Start: 6, End: 9, Term: []byte("ヲ"), Position: 2, Type: analysis.Ideographic},
+				{Start: 9, End: 15, Term: []byte("学ん"), Position: 3, Type: analysis.Ideographic},
+				{Start: 9, End: 15, Term: []byte("マナン"), Position: 3, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("だ"), Position: 4, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("ダ"), Position: 4, Type: analysis.Ideographic},
+				{Start: 18, End: 42, Term: []byte("エルフェンリート"), Position: 5, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name: "uni: replace with katakana",
+			dict: uni.Dict(),
+			opt:  ReadingForm(FormReplace, nil),
+			want: analysis.TokenStream{
+				{Start: 0, End: 6, Term: []byte("ケイザイ"), Position: 1, Type: analysis.Ideographic}, // Note: reading of the lemma
+				{Start: 6, End: 9, Term: []byte("ヲ"), Position: 2, Type: analysis.Ideographic},
+				{Start: 9, End: 15, Term: []byte("マナン"), Position: 3, Type: analysis.Ideographic}, // Note: pronunciation
+				{Start: 15, End: 18, Term: []byte("ダ"), Position: 4, Type: analysis.Ideographic},
+				{Start: 18, End: 27, Term: []byte("エルフ"), Position: 5, Type: analysis.Ideographic},
+				{Start: 27, End: 42, Term: []byte("ェンリート"), Position: 6, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name: "unknown layout: surface",
+			dict: withoutReadingMeta(ipa.Dict(), nil),
+			opt:  ReadingForm(FormReplace, nil),
+			want: analysis.TokenStream{
+				{Start: 0, End: 6, Term: []byte("経済"), Position: 1, Type: analysis.Ideographic},
+				{Start: 6, End: 9, Term: []byte("を"), Position: 2, Type: analysis.Ideographic},
+				{Start: 9, End: 15, Term: []byte("学ん"), Position: 3, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("だ"), Position: 4, Type: analysis.Ideographic},
+				{Start: 18, End: 42, Term: []byte("エルフェンリート"), Position: 5, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name: "declared lemma reading",
+			dict: withoutReadingMeta(ipa.Dict(), dict.ContentsMeta{LemmaReadingIndex: 7}),
+			opt:  ReadingForm(FormReplace, nil),
+			want: analysis.TokenStream{
+				{Start: 0, End: 6, Term: []byte("ケイザイ"), Position: 1, Type: analysis.Ideographic},
+				{Start: 6, End: 9, Term: []byte("ヲ"), Position: 2, Type: analysis.Ideographic},
+				{Start: 9, End: 15, Term: []byte("マナン"), Position: 3, Type: analysis.Ideographic},
+				{Start: 15, End: 18, Term: []byte("ダ"), Position: 4, Type: analysis.Ideographic},
+				{Start: 18, End: 42, Term: []byte("エルフェンリート"), Position: 5, Type: analysis.Ideographic},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := NewJapaneseTokenizer(tt.dict, tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tz.Tokenize(input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// withoutReadingMeta returns a copy of the dictionary whose contents meta has no reading of the surface
+// and has the extra meta.
+func withoutReadingMeta(d *dict.Dict, extra dict.ContentsMeta) *dict.Dict {
+	ret := *d
+	ret.ContentsMeta = dict.ContentsMeta{}
+	for k, v := range d.ContentsMeta {
+		if k != dict.ReadingIndex {
+			ret.ContentsMeta[k] = v
+		}
+	}
+	for k, v := range extra {
+		ret.ContentsMeta[k] = v
+	}
+	return &ret
+}
+
+func TestIsUniDicLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		dict *dict.Dict
+		want bool
+	}{
+		{name: "ipa", dict: ipa.Dict(), want: false},
+		{name: "uni", dict: uni.Dict(), want: true},
+		{name: "unknown layout", dict: withoutReadingMeta(ipa.Dict(), nil), want: false},
+		{name: "declared lemma reading", dict: withoutReadingMeta(ipa.Dict(), dict.ContentsMeta{LemmaReadingIndex: 7}), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUniDicLayout(tt.dict); got != tt.want {
+				t.Errorf("isUniDicLayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJapaneseTokenizer_ReadingFormUserDict(t *testing.T) {
+	ud, err := NewUserDict("", "朝日新聞,朝日 新聞,アサヒ シンブン,カスタム名詞")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := NewJapaneseTokenizer(ipa.Dict(), UserDict(ud), ReadingForm(FormReplace, KatakanaToHiragana))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"あさひしんぶん"}
+	if got := terms(tz.Tokenize([]byte("朝日新聞"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTokenizerConstructor_ReadingForm(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "hiragana",
+			config: map[string]any{"dict": DictIPA, "reading": ReadingHiragana},
+			want:   []string{"ねこ", "が", "すき"},
+		},
+		{
+			name:   "katakana both",
+			config: map[string]any{"dict": DictIPA, "reading": ReadingKatakana, "reading_mode": FormModeBoth},
+			want:   []string{"猫", "ネコ", "が", "ガ", "好き", "スキ"},
+		},
+		{
+			name:    "unsupported reading",
+			config:  map[string]any{"dict": DictIPA, "reading": "kanji"},
+			wantErr: true,
+		},
+		{
+			name:   "romaji",
+			config: map[string]any{"dict": DictIPA, "reading": ReadingRomaji, "romaji_system": RomajiKunrei},
+			want:   []string{"neko", "ga", "suki"},
+		},
+		{
+			name:    "unsupported romaji system",
+			config:  map[string]any{"dict": DictIPA, "reading": ReadingRomaji, "romaji_system": "nihon"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported reading mode",
+			config:  map[string]any{"dict": DictIPA, "reading": ReadingKatakana, "reading_mode": "append"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := TokenizerConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenizerConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := terms(tz.Tokenize([]byte("猫が好き"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKatakanaToHiragana(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "カタカナ", want: "かたかな"},
+		{in: "ヴァイオリン", want: "ゔぁいおりん"},
+		{in: "ヵヶヽヾ", want: "ゕゖゝゞ"},
+		{in: "ヷ漢字ひらがなABC", want: "ヷ漢字ひらがなABC"},
+	}
+	for _, tt := range tests {
+		if got := KatakanaToHiragana(tt.in); got != tt.want {
+			t.Errorf("KatakanaToHiragana(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
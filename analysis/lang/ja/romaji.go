@@ -0,0 +1,281 @@
+package ja
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// RomajiFilterName is the name of the romaji token filter.
+const RomajiFilterName = "ja_romaji"
+
+func init() {
+	if err := registry.RegisterTokenFilter(RomajiFilterName, RomajiFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// Romaji systems.
+const (
+	RomajiHepburn = "hepburn"
+	RomajiKunrei  = "kunrei"
+)
+
+var romajiBase = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa", 'ゕ': "ka", 'ゖ': "ke",
+}
+
+var romajiKunreiBase = map[rune]string{
+	'し': "si", 'ち': "ti", 'つ': "tu", 'ふ': "hu", 'じ': "zi", 'ぢ': "zi",
+}
+
+// romajiYoon represents consonants of the kana followed by small ya, yu, yo and e.
+var romajiYoon = map[rune]string{
+	'き': "ky", 'し': "sh", 'ち': "ch", 'に': "ny", 'ひ': "hy", 'み': "my", 'り': "ry",
+	'ぎ': "gy", 'じ': "j", 'ぢ': "j", 'び': "by", 'ぴ': "py",
+}
+
+var romajiKunreiYoon = map[rune]string{
+	'し': "sy", 'ち': "ty", 'じ': "zy", 'ぢ': "zy",
+}
+
+// romajiForeign represents digraphs for loanwords, they are common to the both systems.
+var romajiForeign = map[string]string{
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo", "ふゅ": "fyu",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du", "てゅ": "tyu", "でゅ": "dyu",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo", "ゔゅ": "vyu",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo", "いぇ": "ye",
+	"つぁ": "tsa", "つぃ": "tsi", "つぇ": "tse", "つぉ": "tso",
+	"くぁ": "kwa", "ぐぁ": "gwa", "すぃ": "si", "ずぃ": "zi",
+}
+
+var romajiYoonVowels = map[rune]string{
+	'ゃ': "a", 'ゅ': "u", 'ょ': "o", 'ぇ': "e",
+}
+
+// RomajiConverter represents a kana to romaji converter.
+type RomajiConverter struct {
+	syllables          map[string]string
+	hepburn            bool
+	collapseLongVowels bool
+}
+
+// NewRomajiConverter returns a romaji converter of the system, hepburn or kunrei.
+// If collapseLongVowels is true, long vowels are collapsed to short ones, e.g. toukyou to tokyo.
+func NewRomajiConverter(system string, collapseLongVowels bool) (*RomajiConverter, error) {
+	var hepburn bool
+	switch strings.ToLower(system) {
+	case RomajiHepburn:
+		hepburn = true
+	case RomajiKunrei:
+	default:
+		return nil, fmt.Errorf("unsupported romaji system: %s", system)
+	}
+	syllables := make(map[string]string, len(romajiBase)+len(romajiYoon)*len(romajiYoonVowels)+len(romajiForeign))
+	for k, v := range romajiBase {
+		syllables[string(k)] = v
+	}
+	for k, v := range romajiYoon {
+		for y, vowel := range romajiYoonVowels {
+			syllables[string([]rune{k, y})] = v + vowel
+		}
+	}
+	for k, v := range romajiForeign {
+		syllables[k] = v
+	}
+	if !hepburn {
+		for k, v := range romajiKunreiBase {
+			syllables[string(k)] = v
+		}
+		for k, v := range romajiKunreiYoon {
+			for y, vowel := range romajiYoonVowels {
+				syllables[string([]rune{k, y})] = v + vowel
+			}
+		}
+	}
+	return &RomajiConverter{
+		syllables:          syllables,
+		hepburn:            hepburn,
+		collapseLongVowels: collapseLongVowels,
+	}, nil
+}
+
+// Convert converts kana in the string to romaji. Other characters are not converted.
+// Half-width katakana is converted as well as full-width katakana.
+// ん followed by a vowel or y is written n', e.g. kan'i, to distinguish it from な行, e.g. kani.
+func (c RomajiConverter) Convert(s string) string {
+	rs := []rune(KatakanaToHiragana(HalfwidthToFullwidthKatakana(s)))
+	var b strings.Builder
+	var (
+		last    byte // the last vowel
+		sokuon  bool
+		hatsuon bool // true if the last syllable is ん.
+	)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch r {
+		case 'っ':
+			sokuon = true
+			i++
+			continue
+		case 'ー':
+			if !c.collapseLongVowels && last != 0 {
+				b.WriteByte(last)
+			}
+			hatsuon = false
+			i++
+			continue
+		}
+		syllable, size := c.syllable(rs[i:])
+		if size == 0 {
+			b.WriteRune(r)
+			last, sokuon, hatsuon = 0, false, false
+			i++
+			continue
+		}
+		i += size
+		if c.collapseLongVowels && isLongVowel(last, syllable) {
+			sokuon = false
+			continue
+		}
+		if sokuon && syllable[0] != 'n' && !isVowel(syllable[0]) {
+			if c.hepburn && strings.HasPrefix(syllable, "ch") {
+				b.WriteByte('t')
+			} else {
+				b.WriteByte(syllable[0])
+			}
+		}
+		if hatsuon && (isVowel(syllable[0]) || syllable[0] == 'y') {
+			b.WriteByte('\'')
+		}
+		b.WriteString(syllable)
+		hatsuon = r == 'ん'
+		last, sokuon = syllable[len(syllable)-1], false
+		if !isVowel(last) {
+			last = 0
+		}
+	}
+	return b.String()
+}
+
+func (c RomajiConverter) syllable(rs []rune) (string, int) {
+	if len(rs) > 1 {
+		if v, ok := c.syllables[string(rs[:2])]; ok {
+			return v, 2
+		}
+	}
+	if v, ok := c.syllables[string(rs[0])]; ok {
+		return v, 1
+	}
+	return "", 0
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'i', 'u', 'e', 'o':
+		return true
+	}
+	return false
+}
+
+// isLongVowel returns true if the syllable is a vowel which prolongs the last vowel,
+// i.e. aa, ii, uu, ee, oo and ou.
+func isLongVowel(last byte, syllable string) bool {
+	if last == 0 || len(syllable) != 1 {
+		return false
+	}
+	return syllable[0] == last || (last == 'o' && syllable[0] == 'u')
+}
+
+// isKana returns true if the string consists of hiragana, katakana, half-width katakana,
+// prolonged sound marks and voiced sound marks.
+func isKana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if _, ok := voicedSoundMarks[r]; ok {
+			continue
+		}
+		if r != 'ー' && r != 'ｰ' && !unicode.In(r, unicode.Hiragana, unicode.Katakana) {
+			return false
+		}
+	}
+	return true
+}
+
+// RomajiFilter represents a token filter which converts kana terms to romaji.
+type RomajiFilter struct {
+	converter *RomajiConverter
+}
+
+// NewRomajiFilter returns a romaji filter.
+func NewRomajiFilter(c *RomajiConverter) *RomajiFilter {
+	return &RomajiFilter{
+		converter: c,
+	}
+}
+
+// Filter converts kana terms to romaji. Terms which have other characters are not converted.
+func (f *RomajiFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if !isKana(string(token.Term)) {
+			continue
+		}
+		s := f.converter.Convert(string(token.Term))
+		if s == "" {
+			continue // e.g. っ and ー, which have no romaji of their own.
+		}
+		token.Term = []byte(s)
+		token.Type = analysis.AlphaNumeric
+	}
+	return input
+}
+
+// RomajiFilterConstructor returns a romaji filter.
+// The config has the system, hepburn (default) or kunrei, and collapse_long_vowels.
+func RomajiFilterConstructor(config map[string]any, _ *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+	c, err := romajiConverterConfig(config["system"], config["collapse_long_vowels"])
+	if err != nil {
+		return nil, err
+	}
+	return NewRomajiFilter(c), nil
+}
+
+func romajiConverterConfig(system, collapse any) (*RomajiConverter, error) {
+	s := RomajiHepburn
+	if system != nil {
+		var ok bool
+		if s, ok = system.(string); !ok {
+			return nil, fmt.Errorf("unsupported romaji system: %v", system)
+		}
+	}
+	var c bool
+	if collapse != nil {
+		var ok bool
+		if c, ok = collapse.(bool); !ok {
+			return nil, fmt.Errorf("collapse_long_vowels must be a boolean: %v", collapse)
+		}
+	}
+	return NewRomajiConverter(s, c)
+}
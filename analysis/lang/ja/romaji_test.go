@@ -0,0 +1,113 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+func TestRomajiConverter_Convert(t *testing.T) {
+	tests := []struct {
+		in       string
+		hepburn  string
+		kunrei   string
+		collapse string
+	}{
+		{in: "とうきょう", hepburn: "toukyou", kunrei: "toukyou", collapse: "tokyo"},
+		{in: "トウキョウ", hepburn: "toukyou", kunrei: "toukyou", collapse: "tokyo"},
+		{in: "しんじゅく", hepburn: "shinjuku", kunrei: "sinzyuku", collapse: "shinjuku"},
+		{in: "ちゃっちゃ", hepburn: "chatcha", kunrei: "tyattya", collapse: "chatcha"},
+		{in: "きっぷ", hepburn: "kippu", kunrei: "kippu", collapse: "kippu"},
+		{in: "ふじさん", hepburn: "fujisan", kunrei: "huzisan", collapse: "fujisan"},
+		{in: "つづく", hepburn: "tsuzuku", kunrei: "tuzuku", collapse: "tsuzuku"},
+		{in: "コンピューター", hepburn: "konpyuutaa", kunrei: "konpyuutaa", collapse: "konpyuta"},
+		{in: "サーバー", hepburn: "saabaa", kunrei: "saabaa", collapse: "saba"},
+		{in: "ヴァイオリン", hepburn: "vaiorin", kunrei: "vaiorin", collapse: "vaiorin"},
+		{in: "ティーシャツ", hepburn: "tiishatsu", kunrei: "tiisyatu", collapse: "tishatsu"},
+		{in: "おおさか", hepburn: "oosaka", kunrei: "oosaka", collapse: "osaka"},
+		{in: "あっ", hepburn: "a", kunrei: "a", collapse: "a"},
+		{in: "東京タワー", hepburn: "東京tawaa", kunrei: "東京tawaa", collapse: "東京tawa"},
+		{in: "カンイ", hepburn: "kan'i", kunrei: "kan'i", collapse: "kan'i"},
+		{in: "かに", hepburn: "kani", kunrei: "kani", collapse: "kani"},
+		{in: "キンヨウビ", hepburn: "kin'youbi", kunrei: "kin'youbi", collapse: "kin'yobi"},
+		{in: "きんえん", hepburn: "kin'en", kunrei: "kin'en", collapse: "kin'en"},
+		{in: "こんにゃく", hepburn: "konnyaku", kunrei: "konnyaku", collapse: "konnyaku"},
+		{in: "ｶﾞｰﾄﾞ", hepburn: "gaado", kunrei: "gaado", collapse: "gado"},
+		{in: "ﾄｳｷｮｳ", hepburn: "toukyou", kunrei: "toukyou", collapse: "tokyo"},
+	}
+	hepburn, err := NewRomajiConverter(RomajiHepburn, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kunrei, err := NewRomajiConverter(RomajiKunrei, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collapse, err := NewRomajiConverter(RomajiHepburn, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := hepburn.Convert(tt.in); got != tt.hepburn {
+				t.Errorf("hepburn: got %q, want %q", got, tt.hepburn)
+			}
+			if got := kunrei.Convert(tt.in); got != tt.kunrei {
+				t.Errorf("kunrei: got %q, want %q", got, tt.kunrei)
+			}
+			if got := collapse.Convert(tt.in); got != tt.collapse {
+				t.Errorf("collapse: got %q, want %q", got, tt.collapse)
+			}
+		})
+	}
+}
+
+func TestRomajiFilter(t *testing.T) {
+	cache := registry.NewCache()
+	f, err := cache.DefineTokenFilter("romaji", map[string]any{
+		"type":                 RomajiFilterName,
+		"collapse_long_vowels": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := analysis.TokenStream{
+		{Term: []byte("トウキョウ"), Position: 1, Start: 0, End: 15, Type: analysis.Ideographic},
+		{Term: []byte("東京"), Position: 2, Start: 15, End: 21, Type: analysis.Ideographic},
+		{Term: []byte("bleve"), Position: 3, Start: 21, End: 26, Type: analysis.AlphaNumeric},
+		{Term: []byte("ｶﾞｰﾄﾞ"), Position: 4, Start: 26, End: 41, Type: analysis.Ideographic},
+		{Term: []byte("っ"), Position: 5, Start: 41, End: 44, Type: analysis.Ideographic},
+		{Term: []byte("ー"), Position: 6, Start: 44, End: 47, Type: analysis.Ideographic},
+	}
+	want := analysis.TokenStream{
+		{Term: []byte("tokyo"), Position: 1, Start: 0, End: 15, Type: analysis.AlphaNumeric},
+		{Term: []byte("東京"), Position: 2, Start: 15, End: 21, Type: analysis.Ideographic},
+		{Term: []byte("bleve"), Position: 3, Start: 21, End: 26, Type: analysis.AlphaNumeric},
+		{Term: []byte("gado"), Position: 4, Start: 26, End: 41, Type: analysis.AlphaNumeric},
+		{Term: []byte("っ"), Position: 5, Start: 41, End: 44, Type: analysis.Ideographic},
+		{Term: []byte("ー"), Position: 6, Start: 44, End: 47, Type: analysis.Ideographic},
+	}
+	if got := f.Filter(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRomajiFilterConstructor_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+	}{
+		{name: "unsupported system", config: map[string]any{"system": "nihon"}},
+		{name: "invalid system type", config: map[string]any{"system": 1}},
+		{name: "invalid collapse_long_vowels type", config: map[string]any{"collapse_long_vowels": "yes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RomajiFilterConstructor(tt.config, registry.NewCache()); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
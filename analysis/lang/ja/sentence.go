@@ -0,0 +1,179 @@
+package ja
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/v2/filter"
+)
+
+// DefaultSentenceSplitter returns the default sentence splitter of the tokenizer.
+func DefaultSentenceSplitter() filter.SentenceSplitter {
+	return filter.SentenceSplitter{
+		Delim:               []rune{'。', '．', '！', '!', '？', '?'},
+		Follower:            []rune{'.', '｣', '」', '』', ')', '）', '｝', '}', '〉', '》'},
+		SkipWhiteSpace:      false,
+		DoubleLineFeedSplit: true,
+		MaxRuneLen:          128,
+	}
+}
+
+var defaultSplitter = DefaultSentenceSplitter()
+
+// SentenceSplitter returns a sentence splitter option.
+// A MaxRuneLen less than or equal to 0 means that the length of sentences is not limited.
+func SentenceSplitter(s filter.SentenceSplitter) TokenizerOption {
+	if s.MaxRuneLen <= 0 {
+		s.MaxRuneLen = math.MaxInt
+	}
+	return func(t *JapaneseTokenizer) error {
+		t.splitter = &s
+		return nil
+	}
+}
+
+// NoSentenceSplit returns an option which disables sentence splitting.
+// The whole input is analyzed as a sentence.
+func NoSentenceSplit() TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		t.splitter = nil
+		return nil
+	}
+}
+
+// sentence represents a sentence of the input.
+type sentence struct {
+	text  string
+	start int
+	// offsets maps a byte offset of the text to the one of the input.
+	// It is nil if the text is a substring of the input starting at start.
+	offsets []int
+	// ends maps a byte offset of the text where a token ends to the one of the input.
+	// If it is nil, the end offsets are derived from the offsets.
+	ends []int
+}
+
+// startOffset returns the start offset in the input of the text starting at i.
+func (s sentence) startOffset(i int) int {
+	if s.offsets == nil {
+		return s.start + i
+	}
+	return s.offsets[i]
+}
+
+// endOffset returns the end offset in the input of the text ending at i.
+func (s sentence) endOffset(i int) int {
+	if s.ends != nil {
+		return s.ends[i]
+	}
+	if s.offsets == nil || i == 0 {
+		return s.startOffset(i)
+	}
+	return s.offsets[i-1] + 1
+}
+
+// sentences splits the input into sentences, and normalizes them if the normalization form is set.
+func (t *JapaneseTokenizer) sentences(input []byte) []sentence {
+	ret := t.splitSentences(input)
+	if t.normForm != nil {
+		for i := range ret {
+			ret[i] = ret[i].normalize(*t.normForm)
+		}
+	}
+	return ret
+}
+
+// splitSentences splits the input into sentences.
+func (t *JapaneseTokenizer) splitSentences(input []byte) []sentence {
+	if t.splitter == nil {
+		return []sentence{{text: string(input)}}
+	}
+	data := input
+	if t.splitter.SkipWhiteSpace {
+		data = bytes.Clone(input) // the splitter overwrites the data to eliminate white spaces.
+	}
+	var ret []sentence
+	for p := 0; p < len(data); {
+		advance, token, _ := t.splitter.ScanSentences(data[p:], true)
+		if advance <= 0 {
+			break
+		}
+		if len(token) > 0 {
+			s := sentence{
+				text:  string(token),
+				start: p,
+			}
+			if t.splitter.SkipWhiteSpace {
+				s.offsets = nonSpaceOffsets(input[p:p+advance], p)
+			}
+			ret = append(ret, s)
+		}
+		p += advance
+	}
+	return ret
+}
+
+// nonSpaceOffsets returns the byte offsets of the non white space characters of b.
+func nonSpaceOffsets(b []byte, base int) []int {
+	ret := make([]int, 0, len(b))
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if !unicode.IsSpace(r) {
+			for j := range size {
+				ret = append(ret, base+i+j)
+			}
+		}
+		i += size
+	}
+	return ret
+}
+
+// sentenceSplitterConfig returns a sentence splitter option from a config value,
+// false or an object of delimiters, followers, skip_white_space, double_line_feed_split and max_rune_len.
+func sentenceSplitterConfig(v any) (TokenizerOption, error) {
+	if ok, isBool := v.(bool); isBool {
+		if ok {
+			return SentenceSplitter(DefaultSentenceSplitter()), nil
+		}
+		return NoSentenceSplit(), nil
+	}
+	config, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.New("sentence_splitter must be a boolean or an object")
+	}
+	s := DefaultSentenceSplitter()
+	if v, ok := config["delimiters"]; ok {
+		delim, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("sentence_splitter.delimiters must be a string: %v", v)
+		}
+		s.Delim = []rune(delim)
+	}
+	if v, ok := config["followers"]; ok {
+		follower, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("sentence_splitter.followers must be a string: %v", v)
+		}
+		s.Follower = []rune(follower)
+	}
+	if v, ok := config["skip_white_space"]; ok {
+		if s.SkipWhiteSpace, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("sentence_splitter.skip_white_space must be a boolean: %v", v)
+		}
+	}
+	if v, ok := config["double_line_feed_split"]; ok {
+		if s.DoubleLineFeedSplit, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("sentence_splitter.double_line_feed_split must be a boolean: %v", v)
+		}
+	}
+	if v, ok := config["max_rune_len"]; ok {
+		if s.MaxRuneLen, ok = intValue(v); !ok {
+			return nil, fmt.Errorf("sentence_splitter.max_rune_len must be an integer: %v", v)
+		}
+	}
+	return SentenceSplitter(s), nil
+}
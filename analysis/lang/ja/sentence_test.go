@@ -0,0 +1,155 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/filter"
+)
+
+func TestJapaneseTokenizer_SentenceSplitter(t *testing.T) {
+	input := []byte("楽しい笑 明日 も\n行くw寿司")
+	tests := []struct {
+		name string
+		opt  TokenizerOption
+		want analysis.TokenStream
+	}{
+		{
+			name: "custom delimiters and skip white spaces",
+			opt: SentenceSplitter(filter.SentenceSplitter{
+				Delim:          []rune("。笑w"),
+				SkipWhiteSpace: true,
+			}),
+			want: analysis.TokenStream{
+				{Start: 0, End: 9, Term: []byte("楽しい"), Position: 1, Type: analysis.Ideographic},
+				{Start: 9, End: 12, Term: []byte("笑"), Position: 2, Type: analysis.Ideographic},
+				{Start: 13, End: 19, Term: []byte("明日"), Position: 3, Type: analysis.Ideographic},
+				{Start: 20, End: 23, Term: []byte("も"), Position: 4, Type: analysis.Ideographic},
+				{Start: 24, End: 30, Term: []byte("行く"), Position: 5, Type: analysis.Ideographic},
+				{Start: 30, End: 31, Term: []byte("w"), Position: 6, Type: analysis.AlphaNumeric},
+				{Start: 31, End: 37, Term: []byte("寿司"), Position: 7, Type: analysis.Ideographic},
+			},
+		},
+		{
+			name: "no sentence split",
+			opt:  NoSentenceSplit(),
+			want: analysis.TokenStream{
+				{Start: 0, End: 9, Term: []byte("楽しい"), Position: 1, Type: analysis.Ideographic},
+				{Start: 9, End: 12, Term: []byte("笑"), Position: 2, Type: analysis.Ideographic},
+				{Start: 12, End: 13, Term: []byte(" "), Position: 3, Type: analysis.Ideographic},
+				{Start: 13, End: 19, Term: []byte("明日"), Position: 4, Type: analysis.Ideographic},
+				{Start: 19, End: 20, Term: []byte(" "), Position: 5, Type: analysis.Ideographic},
+				{Start: 20, End: 23, Term: []byte("も"), Position: 6, Type: analysis.Ideographic},
+				{Start: 23, End: 24, Term: []byte("\n"), Position: 7, Type: analysis.Ideographic},
+				{Start: 24, End: 30, Term: []byte("行く"), Position: 8, Type: analysis.Ideographic},
+				{Start: 30, End: 31, Term: []byte("w"), Position: 9, Type: analysis.AlphaNumeric},
+				{Start: 31, End: 37, Term: []byte("寿司"), Position: 10, Type: analysis.Ideographic},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := NewJapaneseTokenizer(ipa.Dict(), tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tz.Tokenize(input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJapaneseTokenizer_SentenceSplitterMaxRuneLen(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRuneLen int
+		want       []string
+	}{
+		{
+			name:       "split in a word",
+			maxRuneLen: 5,
+			want:       []string{"関西", "国際", "空", "港"},
+		},
+		{
+			name:       "unlimited",
+			maxRuneLen: 0,
+			want:       []string{"関西", "国際", "空港"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := NewJapaneseTokenizer(ipa.Dict(), SentenceSplitter(filter.SentenceSplitter{
+				MaxRuneLen: tt.maxRuneLen,
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := terms(tz.Tokenize([]byte("関西国際空港"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerConstructor_SentenceSplitter(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name     string
+		splitter any
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "disabled",
+			splitter: false,
+			want:     []string{"寿司", "笑", "ラーメン", "。"},
+		},
+		{
+			name: "custom",
+			splitter: map[string]any{
+				"delimiters":             "。笑",
+				"followers":              "」",
+				"skip_white_space":       true,
+				"double_line_feed_split": false,
+				"max_rune_len":           float64(256),
+			},
+			want: []string{"寿司", "笑", "ラーメン", "。"},
+		},
+		{
+			name:     "invalid type",
+			splitter: "。",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid delimiters",
+			splitter: map[string]any{"delimiters": []any{"。"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid max rune len",
+			splitter: map[string]any{"max_rune_len": 1.5},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := TokenizerConstructor(map[string]any{
+				"dict":              DictIPA,
+				"sentence_splitter": tt.splitter,
+			}, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenizerConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := terms(tz.Tokenize([]byte("寿司笑ラーメン。"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,15 +5,22 @@ import (
 
 	"github.com/blevesearch/bleve/v2/analysis"
 	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/dict"
 )
 
 func init() {
 	if err := registry.RegisterTokenMap(StopTagsName, StopTagsTokenMapConstructor); err != nil {
 		panic(err)
 	}
+	if err := registry.RegisterTokenMap(StopTagsUniName, StopTagsUniTokenMapConstructor); err != nil {
+		panic(err)
+	}
 }
 
-const StopTagsName = "stop_tags_ja"
+const (
+	StopTagsName    = "stop_tags_ja"
+	StopTagsUniName = "stop_tags_ja_uni"
+)
 
 // StopTagsBytes is a stop tag list.
 // see. https://github.com/apache/lucene-solr/blob/master/lucene/analysis/kuromoji/src/resources/org/apache/lucene/analysis/ja/stoptags.txt
@@ -27,3 +34,23 @@ func StopTagsTokenMapConstructor(_ map[string]any, _ *registry.Cache) (analysis.
 	err := rv.LoadBytes(StopTagsBytes)
 	return rv, err
 }
+
+// StopTagsUniBytes is a stop tag list for UniDic.
+//
+//go:embed assets/stop_tags_uni.txt
+var StopTagsUniBytes []byte
+
+// StopTagsUniTokenMapConstructor returns a token map for stop tags (for UniDic).
+func StopTagsUniTokenMapConstructor(_ map[string]any, _ *registry.Cache) (analysis.TokenMap, error) {
+	rv := analysis.NewTokenMap()
+	err := rv.LoadBytes(StopTagsUniBytes)
+	return rv, err
+}
+
+// defaultStopTagsName returns the name of the default stop tags for the dictionary.
+func defaultStopTagsName(d *dict.Dict) string {
+	if isUniDicLayout(d) {
+		return StopTagsUniName
+	}
+	return StopTagsName
+}
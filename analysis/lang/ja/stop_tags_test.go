@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/tokenmap"
 	"github.com/blevesearch/bleve/v2/registry"
 )
 
@@ -50,3 +51,132 @@ func TestStopTags(t *testing.T) {
 		t.Errorf("got %+v, want %+v", tags, want)
 	}
 }
+
+func TestTokenizerConstructor_StopTags(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name     string
+		stopTags any
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "default",
+			stopTags: true,
+			want:     []string{"私", "猫"},
+		},
+		{
+			name:     "disabled",
+			stopTags: false,
+			want:     []string{"私", "の", "猫", "です"},
+		},
+		{
+			name:     "token map name",
+			stopTags: "my_stop_tags",
+			want:     []string{"私", "猫", "です"},
+		},
+		{
+			name:     "inline list",
+			stopTags: []any{"助動詞"},
+			want:     []string{"私", "の", "猫"},
+		},
+		{
+			name:     "unknown token map",
+			stopTags: "unknown",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid type",
+			stopTags: 1,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := registry.NewCache()
+			if _, err := cache.DefineTokenMap("my_stop_tags", map[string]any{
+				"type":   tokenmap.Name,
+				"tokens": []any{"助詞-連体化"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			tz, err := TokenizerConstructor(map[string]any{
+				"dict":      DictIPA,
+				"stop_tags": tt.stopTags,
+			}, cache)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TokenizerConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := terms(tz.Tokenize([]byte("私の猫です"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStopTagsUni(t *testing.T) {
+	cache := registry.NewCache()
+	tags, err := cache.TokenMapNamed(StopTagsUniName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := analysis.TokenMap{
+		"接続詞":         true,
+		"感動詞-フィラー":    true,
+		"助動詞":         true,
+		"助詞":          true,
+		"助詞-格助詞":      true,
+		"助詞-係助詞":      true,
+		"助詞-副助詞":      true,
+		"助詞-接続助詞":     true,
+		"助詞-終助詞":      true,
+		"助詞-準体助詞":     true,
+		"記号-一般":       true,
+		"補助記号":        true,
+		"補助記号-一般":     true,
+		"補助記号-句点":     true,
+		"補助記号-読点":     true,
+		"補助記号-括弧開":    true,
+		"補助記号-括弧閉":    true,
+		"補助記号-ＡＡ-一般":  true,
+		"補助記号-ＡＡ-顔文字": true,
+		"空白":          true,
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("got %+v, want %+v", tags, want)
+	}
+}
+
+func TestTokenizerConstructor_DefaultStopTags(t *testing.T) {
+	tests := []struct {
+		dict string
+		want []string
+	}{
+		{
+			dict: DictIPA,
+			want: []string{"私", "猫", "ABC"},
+		},
+		{
+			dict: DictUni,
+			want: []string{"私", "猫", "A", "B", "C"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dict, func(t *testing.T) {
+			requireDict(t, tt.dict)
+			tz, err := TokenizerConstructor(map[string]any{
+				"dict":      tt.dict,
+				"stop_tags": true,
+			}, registry.NewCache())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := terms(tz.Tokenize([]byte("私の猫です。ABC"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
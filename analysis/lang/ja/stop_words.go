@@ -2,6 +2,7 @@ package ja
 
 import (
 	_ "embed"
+	"fmt"
 
 	"github.com/blevesearch/bleve/v2/analysis"
 	"github.com/blevesearch/bleve/v2/analysis/token/stop"
@@ -27,14 +28,63 @@ const StopWordsName = "stop_words_ja"
 var StopWordsBytes []byte
 
 // StopWordsTokenMapConstructor returns a token map for stop words.
-func StopWordsTokenMapConstructor(_ map[string]any, _ *registry.Cache) (analysis.TokenMap, error) {
+// The config has words (a list of extra stop words), file (a path of an extra stop word file)
+// and exclude_defaults (true excludes the default stop words).
+func StopWordsTokenMapConstructor(config map[string]any, _ *registry.Cache) (analysis.TokenMap, error) {
 	rv := analysis.NewTokenMap()
-	err := rv.LoadBytes(StopWordsBytes)
-	return rv, err
+	exclude := false
+	if v, ok := config["exclude_defaults"]; ok {
+		if exclude, ok = v.(bool); !ok {
+			return nil, fmt.Errorf("exclude_defaults must be a boolean: %v", v)
+		}
+	}
+	if !exclude {
+		if err := rv.LoadBytes(StopWordsBytes); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := config["words"]; ok {
+		words, ok := stringsValue(v)
+		if !ok {
+			return nil, fmt.Errorf("words must be a list of strings: %v", v)
+		}
+		for _, w := range words {
+			rv.AddToken(w)
+		}
+	}
+	if v, ok := config["file"]; ok {
+		path, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("file must be a file path: %v", v)
+		}
+		if err := rv.LoadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to load stop words: %w", err)
+		}
+	}
+	return rv, nil
+}
+
+// hasStopWordsConfig returns true if the config has any stop word config keys.
+func hasStopWordsConfig(config map[string]any) bool {
+	for _, k := range []string{"words", "file", "exclude_defaults"} {
+		if _, ok := config[k]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 // StopWordsTokenFilterConstructor returns a token filter for stop words.
-func StopWordsTokenFilterConstructor(_ map[string]any, cache *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+// If the config has words, file or exclude_defaults, the stop words are built from the config
+// in the same manner as the token map, otherwise the stop_words_ja token map is used.
+func StopWordsTokenFilterConstructor(config map[string]any, cache *registry.Cache) (analysis.TokenFilter, error) { //nolint:ireturn
+	if hasStopWordsConfig(config) {
+		tm, err := StopWordsTokenMapConstructor(config, cache)
+		if err != nil {
+			return nil, err
+		}
+		return stop.NewStopTokensFilter(tm), nil
+	}
 	tm, err := cache.TokenMapNamed(StopWordsName)
 	if err != nil {
 		return nil, err
@@ -51,3 +51,121 @@ func TestDefaultStopWords(t *testing.T) {
 		t.Errorf("got %+v, want %+v", words, want)
 	}
 }
+
+func TestStopWordsTokenMapConstructor(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		want    map[string]bool
+		size    int
+		wantErr bool
+	}{
+		{
+			name:   "default",
+			config: map[string]any{},
+			want:   map[string]bool{"これ": true, "為": false},
+			size:   109,
+		},
+		{
+			name:   "words",
+			config: map[string]any{"words": []any{"為", "こと"}},
+			want:   map[string]bool{"これ": true, "為": true, "こと": true},
+			size:   110,
+		},
+		{
+			name:   "file",
+			config: map[string]any{"file": "testdata/stop_words.txt"},
+			want:   map[string]bool{"これ": true, "事": true, "物": true},
+			size:   111,
+		},
+		{
+			name:   "exclude defaults",
+			config: map[string]any{"exclude_defaults": true, "words": []string{"為"}, "file": "testdata/stop_words.txt"},
+			want:   map[string]bool{"これ": false, "為": true, "事": true, "物": true},
+			size:   3,
+		},
+		{
+			name:    "invalid words",
+			config:  map[string]any{"words": "為"},
+			wantErr: true,
+		},
+		{
+			name:    "file not found",
+			config:  map[string]any{"file": "testdata/not_found.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid exclude_defaults",
+			config:  map[string]any{"exclude_defaults": "true"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm, err := StopWordsTokenMapConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StopWordsTokenMapConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(tm) != tt.size {
+				t.Errorf("stop words size: got %d, want %d", len(tm), tt.size)
+			}
+			for k, v := range tt.want {
+				if tm[k] != v {
+					t.Errorf("%q: got %v, want %v", k, tm[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestStopWordsTokenFilterConstructor(t *testing.T) {
+	input := analysis.TokenStream{
+		{Term: []byte("これ")},
+		{Term: []byte("為")},
+		{Term: []byte("猫")},
+	}
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   []string
+	}{
+		{
+			name:   "default",
+			config: map[string]any{},
+			want:   []string{"為", "猫"},
+		},
+		{
+			name:   "words",
+			config: map[string]any{"words": []any{"為"}},
+			want:   []string{"猫"},
+		},
+		{
+			name:   "exclude defaults",
+			config: map[string]any{"exclude_defaults": true, "words": []any{"為"}},
+			want:   []string{"これ", "猫"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := StopWordsTokenFilterConstructor(tt.config, registry.NewCache())
+			if err != nil {
+				t.Fatalf("StopWordsTokenFilterConstructor() unexpected error: %v", err)
+			}
+			in := make(analysis.TokenStream, 0, len(input))
+			for _, v := range input {
+				token := *v
+				in = append(in, &token)
+			}
+			var got []string
+			for _, v := range f.Filter(in) {
+				got = append(got, string(v.Term))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package ja
+
+import (
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+)
+
+// numeralPOS represents POSs of numerals, 名詞-数 (IPA) and 名詞-数詞 (UniDic).
+var numeralPOS = [][]string{
+	{"名詞", "数"},  //nolint:gosmopolitan,asciicheck
+	{"名詞", "数詞"}, //nolint:gosmopolitan,asciicheck
+}
+
+// alphabets represents scripts of alphanumeric tokens.
+var alphabets = []*unicode.RangeTable{
+	unicode.Latin,
+	unicode.Greek,
+	unicode.Cyrillic,
+}
+
+// TokenType returns the token type of the term classified by its characters and POS.
+//
+//	Numeric: digits, or kanji numerals with a numeral POS (e.g. 123, １２３, 三千)
+//	AlphaNumeric: alphabets and digits (e.g. bleve, ｂｌｅｖｅ, v2)
+//	Ideographic: others (e.g. 漢字, かな, カナ)
+func TokenType(term string, pos []string) analysis.TokenType {
+	if term == "" {
+		return analysis.Ideographic
+	}
+	digit, alpha, han := 0, 0, 0
+	for _, r := range term {
+		switch {
+		case unicode.IsDigit(r):
+			digit++
+		case unicode.In(r, alphabets...):
+			alpha++
+		case unicode.Is(unicode.Han, r):
+			han++
+		default:
+			return analysis.Ideographic
+		}
+	}
+	switch {
+	case alpha > 0 && han == 0:
+		return analysis.AlphaNumeric
+	case alpha == 0 && han == 0:
+		return analysis.Numeric
+	case alpha == 0 && isNumeralPOS(pos):
+		return analysis.Numeric
+	}
+	return analysis.Ideographic
+}
+
+func isNumeralPOS(pos []string) bool {
+	for _, v := range numeralPOS {
+		if len(pos) >= len(v) && pos[0] == v[0] && pos[1] == v[1] {
+			return true
+		}
+	}
+	return false
+}
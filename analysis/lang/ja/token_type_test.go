@@ -0,0 +1,40 @@
+package ja
+
+import (
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+)
+
+func TestTokenType(t *testing.T) {
+	tests := []struct {
+		term string
+		pos  []string
+		want analysis.TokenType
+	}{
+		{term: "123", pos: []string{"名詞", "数", "*", "*"}, want: analysis.Numeric},
+		{term: "１２３", pos: []string{"名詞", "数", "*", "*"}, want: analysis.Numeric},
+		{term: "123", want: analysis.Numeric},
+		{term: "三千", pos: []string{"名詞", "数詞", "*", "*"}, want: analysis.Numeric},
+		{term: "三", pos: []string{"名詞", "数", "*", "*"}, want: analysis.Numeric},
+		{term: "三", pos: []string{"名詞", "一般", "*", "*"}, want: analysis.Ideographic},
+		{term: "，", pos: []string{"名詞", "数", "*", "*"}, want: analysis.Ideographic},
+		{term: "bleve", pos: []string{"名詞", "固有名詞", "組織", "*"}, want: analysis.AlphaNumeric},
+		{term: "ｂｌｅｖｅ", want: analysis.AlphaNumeric},
+		{term: "v2", want: analysis.AlphaNumeric},
+		{term: "Ωμέγα", want: analysis.AlphaNumeric},
+		{term: "東京", pos: []string{"名詞", "固有名詞", "地域", "一般"}, want: analysis.Ideographic},
+		{term: "ねこ", want: analysis.Ideographic},
+		{term: "サーバー", want: analysis.Ideographic},
+		{term: "3月", want: analysis.Ideographic},
+		{term: "。", want: analysis.Ideographic},
+		{term: "", want: analysis.Ideographic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			if got := TokenType(tt.term, tt.pos); got != tt.want {
+				t.Errorf("TokenType(%q, %v) = %v, want %v", tt.term, tt.pos, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package ja
 
 import (
-	"bufio"
-	"bytes"
 	"errors"
 	"fmt"
 	"strings"
@@ -10,10 +8,9 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis"
 	"github.com/blevesearch/bleve/v2/registry"
 	"github.com/ikawaha/kagome-dict/dict"
-	"github.com/ikawaha/kagome-dict/ipa"
-	"github.com/ikawaha/kagome-dict/uni"
 	"github.com/ikawaha/kagome/v2/filter"
 	"github.com/ikawaha/kagome/v2/tokenizer"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -22,6 +19,44 @@ const (
 	DictUni = "uni"
 )
 
+// Tokenize modes.
+const (
+	ModeNormal   = "normal"
+	ModeSearch   = "search"
+	ModeExtended = "extended"
+)
+
+var modes = map[string]tokenizer.TokenizeMode{
+	ModeNormal:   tokenizer.Normal,
+	ModeSearch:   tokenizer.Search,
+	ModeExtended: tokenizer.Extended,
+}
+
+// FormMode represents how a converted form of a token is emitted.
+type FormMode int
+
+const (
+	// FormReplace replaces the term with the converted form.
+	FormReplace FormMode = iota + 1
+	// FormBoth emits both the term and the converted form at the same position.
+	FormBoth
+)
+
+// Form modes.
+const (
+	FormModeReplace = "replace"
+	FormModeBoth    = "both"
+)
+
+var formModes = map[string]FormMode{
+	FormModeReplace: FormReplace,
+	FormModeBoth:    FormBoth,
+}
+
+func (m FormMode) valid() bool {
+	return m == FormReplace || m == FormBoth
+}
+
 func init() {
 	if err := registry.RegisterTokenizer(Name, TokenizerConstructor); err != nil {
 		panic(err)
@@ -29,26 +64,85 @@ func init() {
 }
 
 // TokenizerOption represents an option of the japanese tokenizer.
-type TokenizerOption func(t *JapaneseTokenizer)
+type TokenizerOption func(t *JapaneseTokenizer) error
 
 const (
 	posHierarchy      = 4
 	defaultPOSFeature = "*"
 )
 
+// TokenizeMode returns a tokenize mode option.
+// The default mode is tokenizer.Search.
+func TokenizeMode(mode tokenizer.TokenizeMode) TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		switch mode {
+		case tokenizer.Normal, tokenizer.Search, tokenizer.Extended:
+		default:
+			return fmt.Errorf("unsupported tokenize mode: %v", mode)
+		}
+		t.mode = mode
+		return nil
+	}
+}
+
+// DictFromFile returns an option which overrides the system dictionary with a compiled kagome dictionary file.
+func DictFromFile(path string) TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		d, err := LoadDictFile(path)
+		if err != nil {
+			return err
+		}
+		t.sysDict = d
+		return nil
+	}
+}
+
+// UserDict returns a user dictionary option.
+func UserDict(d *dict.UserDict) TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		if d == nil {
+			return errors.New("empty user dictionary")
+		}
+		t.userDict = d
+		return nil
+	}
+}
+
 // StopTagsFilter returns a stop tags filter option.
 func StopTagsFilter(m analysis.TokenMap) TokenizerOption {
-	ps := make([]filter.POS, 0, len(m))
-	for k := range m {
-		pos := strings.Split(k, "-")
-		for i := len(pos); i < posHierarchy; i++ {
-			pos = append(pos, defaultPOSFeature)
-		}
-		ps = append(ps, pos)
+	ft := newExactPOSFilter(m)
+	return func(t *JapaneseTokenizer) error {
+		t.stopTagFilter = ft
+		return nil
 	}
-	ft := filter.NewPOSFilter(ps...)
-	return func(t *JapaneseTokenizer) {
+}
+
+// StopTagsPrefixFilter returns a stop tags filter option which matches POS tags as hierarchical prefixes.
+// A "*" in POS tags matches any feature at the level.
+func StopTagsPrefixFilter(m analysis.TokenMap) TokenizerOption {
+	ft := newPrefixPOSFilter(m)
+	return func(t *JapaneseTokenizer) error {
 		t.stopTagFilter = ft
+		return nil
+	}
+}
+
+// KeepTagsFilter returns a keep tags filter option, which keeps only tokens whose POS matches.
+func KeepTagsFilter(m analysis.TokenMap) TokenizerOption {
+	ft := newExactPOSFilter(m)
+	return func(t *JapaneseTokenizer) error {
+		t.keepTagFilter = ft
+		return nil
+	}
+}
+
+// KeepTagsPrefixFilter returns a keep tags filter option which matches POS tags as hierarchical prefixes.
+// A "*" in POS tags matches any feature at the level.
+func KeepTagsPrefixFilter(m analysis.TokenMap) TokenizerOption {
+	ft := newPrefixPOSFilter(m)
+	return func(t *JapaneseTokenizer) error {
+		t.keepTagFilter = ft
+		return nil
 	}
 }
 
@@ -60,105 +154,205 @@ func BaseFormFilter(m analysis.TokenMap) TokenizerOption {
 		ps = append(ps, pos)
 	}
 	ft := filter.NewPOSFilter(ps...)
-	return func(t *JapaneseTokenizer) {
+	return func(t *JapaneseTokenizer) error {
 		t.baseFormFilter = ft
+		return nil
+	}
+}
+
+// BaseFormPrefixFilter returns a base form filter option which matches POS tags as hierarchical prefixes.
+// A "*" in POS tags matches any feature at the level.
+func BaseFormPrefixFilter(m analysis.TokenMap) TokenizerOption {
+	ft := newPrefixPOSFilter(m)
+	return func(t *JapaneseTokenizer) error {
+		t.baseFormFilter = ft
+		return nil
+	}
+}
+
+// BaseFormMode returns a base form mode option.
+// FormBoth emits both the surface and the base form at the same position.
+// The default mode is FormReplace, which replaces the surface with the base form.
+func BaseFormMode(mode FormMode) TokenizerOption {
+	return func(t *JapaneseTokenizer) error {
+		if !mode.valid() {
+			return fmt.Errorf("unsupported base form mode: %v", mode)
+		}
+		t.baseFormMode = mode
+		return nil
 	}
 }
 
 // JapaneseTokenizer represents a Japanese tokenizer with filters.
 type JapaneseTokenizer struct {
 	*tokenizer.Tokenizer
-	stopTagFilter  *filter.POSFilter
-	baseFormFilter *filter.POSFilter
-}
-
-var splitter = filter.SentenceSplitter{
-	Delim:               []rune{'。', '．', '！', '!', '？', '?'},
-	Follower:            []rune{'.', '｣', '」', '』', ')', '）', '｝', '}', '〉', '》'},
-	SkipWhiteSpace:      false,
-	DoubleLineFeedSplit: true,
-	MaxRuneLen:          128,
+	mode           tokenizer.TokenizeMode
+	sysDict        *dict.Dict
+	userDict       *dict.UserDict
+	splitter       *filter.SentenceSplitter
+	normForm       *norm.Form
+	stopTagFilter  posMatcher
+	keepTagFilter  posMatcher
+	baseFormFilter posMatcher
+	baseFormMode   FormMode
+	readingForm    *readingForm
+	readingLayout  readingLayout
 }
 
 // Tokenize tokenizes the input and filters them.
 func (t *JapaneseTokenizer) Tokenize(input []byte) analysis.TokenStream {
-	scanner := bufio.NewScanner(bytes.NewReader(input))
-	scanner.Split(splitter.ScanSentences)
-	base := 0
 	position := 1
 	var ret analysis.TokenStream
-	for scanner.Scan() {
-		inp := scanner.Text()
-		tokens := t.Analyze(inp, tokenizer.Search)
+	for _, s := range t.sentences(input) {
+		tokens := t.Analyze(s.text, t.mode)
 		tokenLen := len(tokens)
 		if t.stopTagFilter != nil {
-			t.stopTagFilter.Drop(&tokens)
+			dropPOS(t.stopTagFilter, &tokens)
+		}
+		if t.keepTagFilter != nil {
+			keepPOS(t.keepTagFilter, &tokens)
 		}
 		for _, v := range tokens {
-			start := base + v.Position
-			end := base + v.Position + len(v.Surface)
+			start := s.startOffset(v.Position)
+			end := s.endOffset(v.Position + len(v.Surface))
 			term := input[start:end]
-			if t.baseFormFilter != nil {
-				if pos := v.POS(); t.baseFormFilter.Match(pos) {
-					if base, ok := v.BaseForm(); ok {
-						term = []byte(base)
-					}
-				}
+			if s.offsets != nil {
+				term = []byte(v.Surface) // white spaces may be eliminated from the surface.
 			}
-			ret = append(ret, &analysis.Token{
+			pos := v.POS()
+			base, hasBase := t.baseForm(v, pos)
+			if hasBase && t.baseFormMode == FormReplace {
+				term = []byte(base)
+			}
+			token := &analysis.Token{
 				Start:    start,
 				End:      end,
 				Term:     term,
 				Position: position + v.Index,
-				Type:     analysis.Ideographic,
+				Type:     TokenType(v.Surface, pos),
 				KeyWord:  false,
-			})
+			}
+			ret = append(ret, token)
+			if hasBase && t.baseFormMode == FormBoth && base != v.Surface {
+				ret = append(ret, &analysis.Token{
+					Start:    start,
+					End:      end,
+					Term:     []byte(base),
+					Position: token.Position,
+					Type:     token.Type,
+					KeyWord:  false,
+				})
+			}
+			if t.readingForm != nil {
+				ret = t.appendReading(ret, token, v, pos)
+			}
 		}
-		base += len(inp)
 		position += tokenLen
 	}
 	return ret
 }
 
-// NewJapaneseTokenizer returns a Japanese tokenizer.
-func NewJapaneseTokenizer(dict *dict.Dict, opts ...TokenizerOption) *JapaneseTokenizer {
-	t, err := tokenizer.New(dict, tokenizer.OmitBosEos())
-	if err != nil {
-		panic(err)
+// baseForm returns the base form of the token if the base form filter matches the POS.
+func (t *JapaneseTokenizer) baseForm(v tokenizer.Token, pos []string) (string, bool) {
+	if t.baseFormFilter == nil || !t.baseFormFilter.Match(pos) {
+		return "", false
 	}
+	return v.BaseForm()
+}
+
+// NewJapaneseTokenizer returns a Japanese tokenizer.
+// The dictionary may be nil if the DictFromFile option is given.
+func NewJapaneseTokenizer(dict *dict.Dict, opts ...TokenizerOption) (*JapaneseTokenizer, error) {
 	ret := &JapaneseTokenizer{
-		Tokenizer: t,
+		mode:         tokenizer.Search,
+		splitter:     &defaultSplitter,
+		baseFormMode: FormReplace,
 	}
 	for _, opt := range opts {
-		opt(ret)
+		if err := opt(ret); err != nil {
+			return nil, err
+		}
 	}
-	return ret
+	if ret.sysDict != nil {
+		dict = ret.sysDict
+	}
+	tOpts := []tokenizer.Option{tokenizer.OmitBosEos()}
+	if ret.userDict != nil {
+		tOpts = append(tOpts, tokenizer.UserDict(ret.userDict))
+	}
+	t, err := tokenizer.New(dict, tOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tokenizer: %w", err)
+	}
+	ret.Tokenizer = t
+	ret.readingLayout = newReadingLayout(dict)
+	return ret, nil
 }
 
 func TokenizerConstructor(config map[string]any, cache *registry.Cache) (analysis.Tokenizer, error) { //nolint:ireturn
-	var d *dict.Dict
-	kind, ok := config["dict"]
-	if !ok {
-		return nil, errors.New(`config requires dict, e.g. "ipa" or "uni"`)
-	}
-	switch kind {
-	case DictIPA:
-		d = ipa.Dict()
-	case DictUni:
-		d = uni.Dict()
-	default:
-		return nil, fmt.Errorf("unsupported dictionary: %s", kind)
+	d, err := dictConfig(config)
+	if err != nil {
+		return nil, err
 	}
 	var opts []TokenizerOption
-	if ok, _ := config["stop_tags"].(bool); ok {
-		stopTags, err := cache.TokenMapNamed(StopTagsName)
+	if v, ok := config["mode"]; ok {
+		s, _ := v.(string)
+		mode, ok := modes[strings.ToLower(s)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported tokenize mode: %v", v)
+		}
+		opts = append(opts, TokenizeMode(mode))
+	}
+	if v, ok := config["sentence_splitter"]; ok {
+		opt, err := sentenceSplitterConfig(v)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	}
+	if v, ok := config["normalize"]; ok {
+		opt, err := normalizeConfig(v)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	}
+	if _, ok := config["reading"]; ok {
+		opt, err := readingFormConfig(config)
 		if err != nil {
 			return nil, err
 		}
-		opts = append(opts, StopTagsFilter(stopTags))
+		opts = append(opts, opt)
 	}
-	if ok, _ := config["base_form"].(bool); ok {
-		opts = append(opts, BaseFormFilter(DefaultInflected))
+	_, hasPath := config["user_dict"]
+	_, hasEntries := config["user_dict_entries"]
+	if hasPath || hasEntries {
+		path, ok := config["user_dict"].(string)
+		if hasPath && !ok {
+			return nil, fmt.Errorf("user_dict must be a file path: %v", config["user_dict"])
+		}
+		entries, ok := stringsValue(config["user_dict_entries"])
+		if hasEntries && !ok {
+			return nil, fmt.Errorf("user_dict_entries must be a list of strings: %v", config["user_dict_entries"])
+		}
+		ud, err := NewUserDict(path, entries...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build user dictionary: %w", err)
+		}
+		opts = append(opts, UserDict(ud))
+	}
+	posOpts, err := posFilterConfig(config, cache, d)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, posOpts...)
+	if v, ok := config["base_form_mode"]; ok {
+		s, _ := v.(string)
+		mode, ok := formModes[strings.ToLower(s)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported base_form_mode: %v", v)
+		}
+		opts = append(opts, BaseFormMode(mode))
 	}
-	return NewJapaneseTokenizer(d, opts...), nil
+	return NewJapaneseTokenizer(d, opts...)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/blevesearch/bleve/v2/registry"
 	"github.com/ikawaha/kagome-dict/dict"
 	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
 )
 
 func TestJapaneseTokenizer_Tokenize(t *testing.T) {
@@ -51,6 +52,48 @@ func TestJapaneseTokenizer_Tokenize(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "文字種",
+			input: []byte("bleveで３千円"),
+			dict:  ipa.Dict(),
+			want: analysis.TokenStream{
+				{
+					Start:    0,
+					End:      5,
+					Term:     []byte("bleve"),
+					Position: 1,
+					Type:     analysis.AlphaNumeric,
+				},
+				{
+					Start:    5,
+					End:      8,
+					Term:     []byte("で"),
+					Position: 2,
+					Type:     analysis.Ideographic,
+				},
+				{
+					Start:    8,
+					End:      11,
+					Term:     []byte("３"),
+					Position: 3,
+					Type:     analysis.Numeric,
+				},
+				{
+					Start:    11,
+					End:      14,
+					Term:     []byte("千"),
+					Position: 4,
+					Type:     analysis.Numeric,
+				},
+				{
+					Start:    14,
+					End:      17,
+					Term:     []byte("円"),
+					Position: 5,
+					Type:     analysis.Ideographic,
+				},
+			},
+		},
 		{
 			name:  "文分割あり",
 			dict:  ipa.Dict(),
@@ -215,7 +258,10 @@ func TestJapaneseTokenizer_Tokenize(t *testing.T) {
 	}
 	for _, v := range tests {
 		t.Run(v.name, func(t *testing.T) {
-			tz := NewJapaneseTokenizer(v.dict, v.opts...)
+			tz, err := NewJapaneseTokenizer(v.dict, v.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
 			if got := tz.Tokenize(v.input); !reflect.DeepEqual(got, v.want) {
 				t.Errorf("got %+v, want %+v", got, v.want)
 			}
@@ -231,7 +277,9 @@ func TestTokenizerDefaultStopTagFilterMatch(t *testing.T) {
 	}
 	var tnz JapaneseTokenizer
 	opt := StopTagsFilter(stopTags)
-	opt(&tnz)
+	if err := opt(&tnz); err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		name string
@@ -277,3 +325,167 @@ func TestTokenizerDefaultStopTagFilterMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestJapaneseTokenizer_TokenizeMode(t *testing.T) {
+	tests := []struct {
+		name string
+		mode tokenizer.TokenizeMode
+		want []string
+	}{
+		{
+			name: "normal",
+			mode: tokenizer.Normal,
+			want: []string{"関西国際空港", "ラフィーネ"},
+		},
+		{
+			name: "search",
+			mode: tokenizer.Search,
+			want: []string{"関西", "国際", "空港", "ラフィーネ"},
+		},
+		{
+			name: "extended",
+			mode: tokenizer.Extended,
+			want: []string{"関西", "国際", "空港", "ラ", "フ", "ィ", "ー", "ネ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz, err := NewJapaneseTokenizer(ipa.Dict(), TokenizeMode(tt.mode))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := terms(tz.Tokenize([]byte("関西国際空港ラフィーネ"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJapaneseTokenizer_BaseFormMode(t *testing.T) {
+	tz, err := NewJapaneseTokenizer(ipa.Dict(), BaseFormFilter(DefaultInflected), BaseFormMode(FormBoth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := analysis.TokenStream{
+		{Start: 0, End: 6, Term: []byte("踊ら"), Position: 1, Type: analysis.Ideographic},
+		{Start: 0, End: 6, Term: []byte("踊る"), Position: 1, Type: analysis.Ideographic},
+		{Start: 6, End: 9, Term: []byte("さ"), Position: 2, Type: analysis.Ideographic},
+		{Start: 6, End: 9, Term: []byte("する"), Position: 2, Type: analysis.Ideographic},
+		{Start: 9, End: 12, Term: []byte("れ"), Position: 3, Type: analysis.Ideographic},
+		{Start: 9, End: 12, Term: []byte("れる"), Position: 3, Type: analysis.Ideographic},
+		{Start: 12, End: 15, Term: []byte("て"), Position: 4, Type: analysis.Ideographic},
+		{Start: 15, End: 21, Term: []byte("いる"), Position: 5, Type: analysis.Ideographic}, // Note: same as the base form
+	}
+	if got := tz.Tokenize([]byte("踊らされている")); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestNewJapaneseTokenizer_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		dict *dict.Dict
+		opts []TokenizerOption
+	}{
+		{
+			name: "empty dictionary",
+			dict: nil,
+		},
+		{
+			name: "unsupported tokenize mode",
+			dict: ipa.Dict(),
+			opts: []TokenizerOption{TokenizeMode(tokenizer.TokenizeMode(0))},
+		},
+		{
+			name: "empty user dictionary",
+			dict: ipa.Dict(),
+			opts: []TokenizerOption{UserDict(nil)},
+		},
+		{
+			name: "unsupported reading form mode",
+			dict: ipa.Dict(),
+			opts: []TokenizerOption{ReadingForm(FormMode(0), nil)},
+		},
+		{
+			name: "unsupported base form mode",
+			dict: ipa.Dict(),
+			opts: []TokenizerOption{BaseFormMode(FormMode(0))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJapaneseTokenizer(tt.dict, tt.opts...); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestTokenizerConstructor(t *testing.T) {
+	requireDict(t, DictIPA)
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr bool
+	}{
+		{
+			name:   "ipa",
+			config: map[string]any{"dict": DictIPA},
+		},
+		{
+			name:   "mode",
+			config: map[string]any{"dict": DictIPA, "mode": ModeExtended},
+		},
+		{
+			name:    "unsupported base form mode",
+			config:  map[string]any{"dict": DictIPA, "base_form": true, "base_form_mode": "append"},
+			wantErr: true,
+		},
+		{
+			name:    "user dict not found",
+			config:  map[string]any{"dict": DictIPA, "user_dict": "testdata/not_found.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid user dict entries type",
+			config:  map[string]any{"dict": DictIPA, "user_dict_entries": "朝日新聞,朝日新聞,アサヒシンブン,カスタム名詞"},
+			wantErr: true,
+		},
+		{
+			name:    "no dict",
+			config:  map[string]any{},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported dict",
+			config:  map[string]any{"dict": "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported mode",
+			config:  map[string]any{"dict": DictIPA, "mode": "fast"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode type",
+			config:  map[string]any{"dict": DictIPA, "mode": 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TokenizerConstructor(tt.config, registry.NewCache())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TokenizerConstructor(%+v) error = %v, wantErr %v", tt.config, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func terms(ts analysis.TokenStream) []string {
+	ret := make([]string, 0, len(ts))
+	for _, v := range ts {
+		ret = append(ret, string(v.Term))
+	}
+	return ret
+}
@@ -0,0 +1,40 @@
+package ja
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+// NewUserDict builds a user dictionary from a file and inline entries.
+// Both are in the kagome (Lucene) user dictionary CSV format, e.g.
+//
+//	日本経済新聞,日本 経済 新聞,ニホン ケイザイ シンブン,カスタム名詞
+//
+// An empty path means no file.
+func NewUserDict(path string, entries ...string) (*dict.UserDict, error) {
+	var records dict.UserDictRecords
+	if path != "" {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close() //nolint:errcheck
+		r, err := dict.NewUserDicRecords(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user dictionary %s: %w", path, err)
+		}
+		records = append(records, r...)
+	}
+	if len(entries) > 0 {
+		r, err := dict.NewUserDicRecords(strings.NewReader(strings.Join(entries, "\n")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid user dictionary entries: %w", err)
+		}
+		records = append(records, r...)
+	}
+	return records.NewUserDict()
+}
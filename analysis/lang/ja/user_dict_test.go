@@ -0,0 +1,86 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/ikawaha/kagome-dict/ipa"
+)
+
+func TestNewUserDict(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		entries []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "file",
+			path: "testdata/user_dict.txt",
+			want: []string{"関西国際空港", "に", "朝日", "新聞", "が"},
+		},
+		{
+			name:    "entries",
+			entries: []string{"朝日新聞,朝日新聞,アサヒシンブン,カスタム名詞"},
+			want:    []string{"関西", "国際", "空港", "に", "朝日新聞", "が"},
+		},
+		{
+			name:    "file and entries",
+			path:    "testdata/user_dict.txt",
+			entries: []string{"朝日新聞,朝日 新聞,アサヒ シンブン,カスタム名詞"},
+			want:    []string{"関西国際空港", "に", "朝日新聞", "が"},
+		},
+		{
+			name:    "file not found",
+			path:    "testdata/not_found.txt",
+			wantErr: true,
+		},
+		{
+			name:    "invalid entry",
+			entries: []string{"朝日新聞,アサヒシンブン"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated entries",
+			path:    "testdata/user_dict.txt",
+			entries: []string{"関西国際空港,関西 国際 空港,カンサイ コクサイ クウコウ,カスタム名詞"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ud, err := NewUserDict(tt.path, tt.entries...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewUserDict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			tz, err := NewJapaneseTokenizer(ipa.Dict(), UserDict(ud))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := terms(tz.Tokenize([]byte("関西国際空港に朝日新聞が"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerConstructor_UserDict(t *testing.T) {
+	requireDict(t, DictIPA)
+	tz, err := TokenizerConstructor(map[string]any{
+		"dict":              DictIPA,
+		"user_dict":         "testdata/user_dict.txt",
+		"user_dict_entries": []any{"朝日新聞,朝日新聞,アサヒシンブン,カスタム名詞"},
+	}, registry.NewCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"関西国際空港", "に", "朝日新聞", "が"}
+	if got := terms(tz.Tokenize([]byte("関西国際空港に朝日新聞が"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -7,9 +7,7 @@ import (
 	"os"
 
 	"github.com/blevesearch/bleve/v2"
-	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
-	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
 	"github.com/ikawaha/bleveplugin/analysis/lang/ja"
 )
 
@@ -26,7 +24,7 @@ func run(_ []string) error {
 	keywordFieldMapping := bleve.NewTextFieldMapping()
 	keywordFieldMapping.Analyzer = keyword.Name
 	jaTextFieldMapping := bleve.NewTextFieldMapping()
-	jaTextFieldMapping.Analyzer = "ja"
+	jaTextFieldMapping.Analyzer = ja.AnalyzerName
 	dm := bleve.NewDocumentMapping()
 	dm.AddFieldMappingsAt("type", keywordFieldMapping)
 	dm.AddFieldMappingsAt("id", jaTextFieldMapping)
@@ -37,24 +35,6 @@ func run(_ []string) error {
 	im := bleve.NewIndexMapping()
 	im.TypeField = "type"
 	im.AddDocumentMapping("book", dm)
-	if err := im.AddCustomTokenizer("ja_tokenizer", map[string]any{
-		"type":      ja.Name,
-		"dict":      ja.DictIPA,
-		"base_form": true,
-		"stop_tags": true,
-	}); err != nil {
-		return fmt.Errorf("failed to create ja tokenizer: %w", err)
-	}
-	if err := im.AddCustomAnalyzer("ja", map[string]any{
-		"type":      custom.Name,
-		"tokenizer": "ja_tokenizer",
-		"token_filters": []string{
-			ja.StopWordsName,
-			lowercase.Name,
-		},
-	}); err != nil {
-		return fmt.Errorf("failed to create ja analyzer: %w", err)
-	}
 
 	// index
 	index, err := bleve.NewMemOnly(im)